@@ -13,6 +13,11 @@ func main() {
 	}
 
 	if err := application.Run(); err != nil {
+		_ = application.Close()
+		log.Fatal(err)
+	}
+
+	if err := application.Close(); err != nil {
 		log.Fatal(err)
 	}
 }
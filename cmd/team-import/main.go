@@ -0,0 +1,85 @@
+// Command team-import loads teams and members from a YAML or CSV file into
+// the database configured by the service environment variables.
+//
+//	team-import [-dry-run] [-format yaml|csv] teams.yaml
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/app"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+	"github.com/CodebyTecs/pr-assign-service/internal/teamimport"
+)
+
+func main() {
+	dryRun := flag.Bool("dry-run", false, "validate the file without writing anything")
+	formatName := flag.String("format", "", "yaml or csv (default: taken from the file extension)")
+	flag.Parse()
+
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: team-import [-dry-run] [-format yaml|csv] FILE")
+		os.Exit(2)
+	}
+	path := flag.Arg(0)
+
+	if *formatName == "" {
+		*formatName = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	format, err := teamimport.ParseFormat(*formatName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+
+	env, err := app.New()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	teamSvc := service.NewTeamService(
+		repository.NewUserRepository(env.Postgres),
+		repository.NewTeamRepository(env.Postgres),
+		repository.NewCodeownersRepository(env.Postgres),
+		repository.NewReviewRulesRepository(env.Postgres),
+		repository.NewTransactor(env.Postgres),
+	)
+
+	ctx := service.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: "cli:team-import", Role: domain.RoleAdmin})
+	result, err := teamSvc.Import(ctx, service.ImportTeamsInput{Format: format, Body: f, DryRun: *dryRun})
+	_ = env.Close()
+
+	if errors.Is(err, domain.ErrInvalidImport) {
+		for _, e := range result.Errors {
+			if e.Field != "" {
+				fmt.Fprintf(os.Stderr, "%s:%d: %s %s\n", path, e.Row, e.Field, e.Message)
+			} else {
+				fmt.Fprintf(os.Stderr, "%s:%d: %s\n", path, e.Row, e.Message)
+			}
+		}
+		os.Exit(1)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	verb := "imported"
+	if result.DryRun {
+		verb = "would import"
+	}
+	fmt.Printf("%s %d teams: %d users created, %d users updated\n", verb, result.TeamsCreated, result.UsersCreated, result.UsersUpdated)
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+)
+
+// maxArchiveSize bounds the body of /admin/import.
+const maxArchiveSize = 256 << 20
+
+type AdminHandler struct {
+	adminService service.AdminService
+}
+
+func NewAdminHandler(adminService service.AdminService) *AdminHandler {
+	return &AdminHandler{adminService: adminService}
+}
+
+// exportWriter sends the archive headers with the first write, so errors
+// raised before any data is produced can still be answered with JSON.
+type exportWriter struct {
+	w       http.ResponseWriter
+	started bool
+}
+
+func (e *exportWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.started = true
+		name := fmt.Sprintf("pr-assign-export-%s.jsonl", time.Now().UTC().Format("20060102T150405Z"))
+		e.w.Header().Set("Content-Type", "application/x-ndjson")
+		e.w.Header().Set("Content-Disposition", `attachment; filename="`+name+`"`)
+		e.w.WriteHeader(http.StatusOK)
+	}
+	return e.w.Write(p)
+}
+
+func (h *AdminHandler) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ew := &exportWriter{w: w}
+	err := h.adminService.Export(ctx, ew)
+	if err == nil {
+		return
+	}
+	if ew.started {
+		// The status line is already sent; the truncated archive fails to import.
+		logger.FromContext(ctx).Error("export aborted", zap.Error(err))
+		return
+	}
+
+	switch {
+	case errors.Is(err, domain.ErrUnauthorized):
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+	case errors.Is(err, domain.ErrForbidden):
+		writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+	default:
+		writeInternalError(w, r, "INTERNAL", err)
+	}
+}
+
+func (h *AdminHandler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	mode := domain.RestoreMode(r.URL.Query().Get("mode"))
+	switch mode {
+	case "":
+		mode = domain.RestoreModeRestore
+	case domain.RestoreModeRestore, domain.RestoreModeMerge:
+	default:
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "mode must be restore or merge")
+		return
+	}
+
+	result, err := h.adminService.Import(ctx, service.ImportArchiveInput{
+		Body: http.MaxBytesReader(w, r.Body, maxArchiveSize),
+		Mode: mode,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidImport):
+			var resp struct {
+				errorBody
+				Rows []domain.ImportRowError `json:"rows"`
+			}
+			resp.Error.Code = "IMPORT_INVALID"
+			resp.Error.Message = "archive is invalid"
+			resp.Rows = result.Errors
+			writeJSON(w, http.StatusUnprocessableEntity, resp)
+			return
+		case errors.Is(err, domain.ErrNotEmpty):
+			writeError(w, http.StatusConflict, "NOT_EMPTY", "database is not empty, use mode=merge")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+)
+
+type AuthHandler struct {
+	authService service.AuthService
+}
+
+func NewAuthHandler(authService service.AuthService) *AuthHandler {
+	return &AuthHandler{authService: authService}
+}
+
+type createTokenRequest struct {
+	Name     string      `json:"name"`
+	Role     domain.Role `json:"role"`
+	TeamName string      `json:"team_name"`
+	UserID   string      `json:"user_id"`
+}
+
+func (h *AuthHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req createTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "name is required")
+		return
+	}
+	if !req.Role.Valid() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "role must be one of admin, team_lead, bot, read_only")
+		return
+	}
+	if req.Role == domain.RoleTeamLead && req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required for team_lead")
+		return
+	}
+
+	input := service.IssueTokenInput{
+		Name:     req.Name,
+		Role:     req.Role,
+		TeamName: req.TeamName,
+		UserID:   req.UserID,
+	}
+
+	raw, token, err := h.authService.IssueToken(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "team or user not found")
+			return
+		case errors.Is(err, domain.ErrUserDeleted):
+			writeError(w, http.StatusConflict, "USER_DELETED", "user is offboarded")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		Token    string           `json:"token"`
+		APIToken *domain.APIToken `json:"api_token"`
+	}{
+		Token:    raw,
+		APIToken: token,
+	}
+
+	writeJSON(w, http.StatusCreated, resp)
+}
+
+type revokeTokenRequest struct {
+	TokenID string `json:"token_id"`
+}
+
+func (h *AuthHandler) RevokeToken(w http.ResponseWriter, r *http.Request) {
+	var req revokeTokenRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TokenID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "token_id is required")
+		return
+	}
+
+	h.revokeToken(w, r, req.TokenID)
+}
+
+// RevokeTokenV2 handles DELETE /v2/auth/tokens/{id}.
+func (h *AuthHandler) RevokeTokenV2(w http.ResponseWriter, r *http.Request) {
+	h.revokeToken(w, r, r.PathValue("id"))
+}
+
+func (h *AuthHandler) revokeToken(w http.ResponseWriter, r *http.Request, tokenID string) {
+	ctx := r.Context()
+
+	err := h.authService.RevokeToken(ctx, tokenID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "token not found or already revoked")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		TokenID string `json:"token_id"`
+		Revoked bool   `json:"revoked"`
+	}{
+		TokenID: tokenID,
+		Revoked: true,
+	})
+}
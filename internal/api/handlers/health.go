@@ -0,0 +1,33 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+)
+
+type HealthHandler struct {
+	healthService service.HealthService
+}
+
+func NewHealthHandler(healthService service.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
+}
+
+func (h *HealthHandler) Healthz(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, h.healthService.Liveness(r.Context()))
+}
+
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	writeHealthReport(w, h.healthService.Readiness(r.Context()))
+}
+
+func writeHealthReport(w http.ResponseWriter, report *domain.HealthReport) {
+	status := http.StatusOK
+	if report.Status != domain.HealthStatusOK {
+		status = http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, status, report)
+}
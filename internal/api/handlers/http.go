@@ -1,8 +1,16 @@
 package handlers
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 )
 
 type errorBody struct {
@@ -24,3 +32,44 @@ func writeError(w http.ResponseWriter, status int, code, message string) {
 	body.Error.Message = message
 	writeJSON(w, status, body)
 }
+
+// writeInternalError logs the underlying error with the request logger and
+// responds with a generic message so internals never leak to the client.
+func writeInternalError(w http.ResponseWriter, r *http.Request, code string, err error) {
+	logger.FromContext(r.Context()).Error("request failed", zap.String("code", code), zap.Error(err))
+	writeError(w, http.StatusInternalServerError, code, "internal error")
+}
+
+// writeJSONWithETag writes v with an ETag derived from its encoding and
+// answers 304 Not Modified when the request's If-None-Match already matches it.
+func writeJSONWithETag(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(v); err != nil {
+		writeInternalError(w, r, "INTERNAL", err)
+		return
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_, _ = w.Write(buf.Bytes())
+}
+
+// etagMatches implements the weak comparison If-None-Match requires.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+)
+
+// parseListPRsQuery reads the shared status, created_after, sort, cursor and limit parameters.
+func parseListPRsQuery(r *http.Request) (service.ListPRsInput, error) {
+	q := r.URL.Query()
+
+	input := service.ListPRsInput{
+		Cursor: q.Get("cursor"),
+	}
+
+	switch status := domain.PRStatus(q.Get("status")); status {
+	case "", domain.PRStatusOpen, domain.PRStatusMerged:
+		input.Status = status
+	default:
+		return input, errors.New("status must be OPEN or MERGED")
+	}
+
+	switch sort := domain.SortOrder(q.Get("sort")); sort {
+	case "", domain.SortAsc, domain.SortDesc:
+		input.Sort = sort
+	default:
+		return input, errors.New("sort must be asc or desc")
+	}
+
+	if v := q.Get("created_after"); v != "" {
+		t, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return input, errors.New("created_after must be an RFC 3339 timestamp")
+		}
+		input.CreatedAfter = &t
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		return input, err
+	}
+	input.Limit = limit
+
+	return input, nil
+}
+
+// parseListUsersQuery reads team_name, is_active, q, cursor and limit.
+func parseListUsersQuery(r *http.Request) (service.ListUsersInput, error) {
+	q := r.URL.Query()
+
+	input := service.ListUsersInput{
+		TeamName: q.Get("team_name"),
+		Query:    q.Get("q"),
+		Cursor:   q.Get("cursor"),
+	}
+
+	if v := q.Get("is_active"); v != "" {
+		isActive, err := strconv.ParseBool(v)
+		if err != nil {
+			return input, errors.New("is_active must be true or false")
+		}
+		input.IsActive = &isActive
+	}
+
+	limit, err := parseLimit(r)
+	if err != nil {
+		return input, err
+	}
+	input.Limit = limit
+
+	return input, nil
+}
+
+// parseLimit returns the limit query parameter, or 0 when it is absent.
+func parseLimit(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(v)
+	if err != nil || limit < 1 || limit > service.MaxPageSize {
+		return 0, errors.New("limit must be between 1 and " + strconv.Itoa(service.MaxPageSize))
+	}
+	return limit, nil
+}
@@ -20,9 +20,11 @@ func NewPRHandler(prService service.PRService) *PRHandler {
 }
 
 type prCreateRequest struct {
-	PRId     string `json:"pull_request_id"`
-	PRName   string `json:"pull_request_name"`
-	AuthorID string `json:"author_id"`
+	PRId         string   `json:"pull_request_id"`
+	PRName       string   `json:"pull_request_name"`
+	AuthorID     string   `json:"author_id"`
+	ChangedFiles []string `json:"changed_files"`
+	RequiredTags []string `json:"required_tags"`
 }
 
 func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -47,12 +49,14 @@ func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	input := service.CreatePRInput{
-		ID:     req.PRId,
-		Name:   req.PRName,
-		Author: req.AuthorID,
+		ID:           req.PRId,
+		Name:         req.PRName,
+		Author:       req.AuthorID,
+		Files:        req.ChangedFiles,
+		RequiredTags: req.RequiredTags,
 	}
 
-	pr, err := h.prService.Create(ctx, input)
+	pr, assigned, err := h.prService.Create(ctx, input)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrNotFound):
@@ -62,29 +66,218 @@ func (h *PRHandler) Create(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, domain.ErrPRExists):
 			writeError(w, http.StatusConflict, "PR_EXISTS", "PR id already exists")
 			return
+		case errors.Is(err, domain.ErrAllAtCapacity):
+			writeError(w, http.StatusConflict, "ALL_AT_CAPACITY", "all candidates reached their open review limit")
+			return
+		case errors.Is(err, domain.ErrUnknownTag):
+			writeError(w, http.StatusBadRequest, "UNKNOWN_TAG", err.Error())
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
 
 		default:
-			writeError(w, http.StatusInternalServerError, "INTERNAL", "internal error")
+			writeInternalError(w, r, "INTERNAL", err)
 			return
 		}
 	}
 
 	resp := struct {
-		PR *domain.PullRequest `json:"pr"`
+		PR         *domain.PullRequest       `json:"pr"`
+		Assignment []domain.AssignedReviewer `json:"assignment"`
 	}{
-		PR: pr,
+		PR:         pr,
+		Assignment: assigned,
 	}
 
 	writeJSON(w, http.StatusCreated, resp)
 }
 
+// Preview handles POST /pullRequest/preview and POST /v2/pull-requests/preview.
+// The body is that of Create; only author_id is required.
+func (h *PRHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req prCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.AuthorID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "author_id is required")
+		return
+	}
+
+	preview, err := h.prService.Preview(ctx, service.CreatePRInput{
+		ID:           req.PRId,
+		Name:         req.PRName,
+		Author:       req.AuthorID,
+		Files:        req.ChangedFiles,
+		RequiredTags: req.RequiredTags,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "author or team not found")
+			return
+		case errors.Is(err, domain.ErrUnknownTag):
+			writeError(w, http.StatusBadRequest, "UNKNOWN_TAG", err.Error())
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		Preview *domain.AssignmentPreview `json:"preview"`
+	}{
+		Preview: preview,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (h *PRHandler) Get(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pull_request_id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	h.get(w, r, id)
+}
+
+// GetV2 handles GET /v2/pull-requests/{id}.
+func (h *PRHandler) GetV2(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, r.PathValue("id"))
+}
+
+func (h *PRHandler) get(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	pr, err := h.prService.Get(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "pullRequest not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		PR *domain.PullRequestDetails `json:"pr"`
+	}{
+		PR: pr,
+	}
+
+	writeJSONWithETag(w, r, http.StatusOK, resp)
+}
+
+// Decisions handles GET /pullRequest/decisions.
+func (h *PRHandler) Decisions(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("pull_request_id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	h.decisions(w, r, id)
+}
+
+// DecisionsV2 handles GET /v2/pull-requests/{id}/decisions.
+func (h *PRHandler) DecisionsV2(w http.ResponseWriter, r *http.Request) {
+	h.decisions(w, r, r.PathValue("id"))
+}
+
+func (h *PRHandler) decisions(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	decisions, err := h.prService.ListDecisions(ctx, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "pullRequest not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		PullRequestID string                      `json:"pull_request_id"`
+		Decisions     []domain.AssignmentDecision `json:"decisions"`
+	}{
+		PullRequestID: id,
+		Decisions:     decisions,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// List handles GET /pullRequest/list and GET /v2/pull-requests.
+func (h *PRHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input, err := parseListPRsQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+	input.AuthorID = r.URL.Query().Get("author_id")
+	input.TeamName = r.URL.Query().Get("team_name")
+
+	page, err := h.prService.List(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCursor):
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid cursor")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
 type prMergeRequest struct {
 	PRId string `json:"pull_request_id"`
 }
 
 func (h *PRHandler) Merge(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	var req prMergeRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
@@ -95,14 +288,33 @@ func (h *PRHandler) Merge(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	pr, err := h.prService.Merge(ctx, req.PRId)
+	h.merge(w, r, req.PRId)
+}
+
+// MergeV2 handles POST /v2/pull-requests/{id}/merge.
+func (h *PRHandler) MergeV2(w http.ResponseWriter, r *http.Request) {
+	h.merge(w, r, r.PathValue("id"))
+}
+
+func (h *PRHandler) merge(w http.ResponseWriter, r *http.Request, id string) {
+	ctx := r.Context()
+
+	pr, err := h.prService.Merge(ctx, id)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
 			writeError(w, http.StatusNotFound, "NOT_FOUND", "pullRequest not found")
 			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL", "internal error")
-		return
 	}
 
 	resp := struct {
@@ -120,8 +332,6 @@ type prReassignRequest struct {
 }
 
 func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	var req prReassignRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
@@ -136,12 +346,38 @@ func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	input := service.ReassignReviewerInput{
+	h.reassign(w, r, service.ReassignReviewerInput{
 		PullRequestID: req.PRId,
 		ReviewerID:    req.ReviewerID,
+	})
+}
+
+type prReassignV2Request struct {
+	ReviewerID string `json:"old_user_id"`
+}
+
+// ReassignV2 handles POST /v2/pull-requests/{id}/reassign.
+func (h *PRHandler) ReassignV2(w http.ResponseWriter, r *http.Request) {
+	var req prReassignV2Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "old_user_id is required")
+		return
 	}
 
-	pr, id, err := h.prService.Reassign(ctx, input)
+	h.reassign(w, r, service.ReassignReviewerInput{
+		PullRequestID: r.PathValue("id"),
+		ReviewerID:    req.ReviewerID,
+	})
+}
+
+func (h *PRHandler) reassign(w http.ResponseWriter, r *http.Request, input service.ReassignReviewerInput) {
+	ctx := r.Context()
+
+	pr, assigned, err := h.prService.Reassign(ctx, input)
 	if err != nil {
 		switch {
 		case errors.Is(err, domain.ErrNotFound):
@@ -156,18 +392,366 @@ func (h *PRHandler) Reassign(w http.ResponseWriter, r *http.Request) {
 		case errors.Is(err, domain.ErrNoCandidate):
 			writeError(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
 			return
+		case errors.Is(err, domain.ErrAllAtCapacity):
+			writeError(w, http.StatusConflict, "ALL_AT_CAPACITY", "all candidates reached their open review limit")
+			return
+		case errors.Is(err, domain.ErrMandatoryReviewer):
+			writeError(w, http.StatusConflict, "MANDATORY_REVIEWER", "no other mandatory reviewer can replace this one")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
 		default:
-			writeError(w, http.StatusInternalServerError, "INTERNAL", "internal error")
+			writeInternalError(w, r, "INTERNAL", err)
 			return
 		}
 	}
 
 	resp := struct {
-		PR         *domain.PullRequest `json:"pr"`
-		ReplacedBy string              `json:"replaced_by"`
+		PR         *domain.PullRequest      `json:"pr"`
+		ReplacedBy string                   `json:"replaced_by"`
+		Assignment *domain.AssignedReviewer `json:"assignment"`
 	}{
 		PR:         pr,
-		ReplacedBy: id,
+		ReplacedBy: assigned.UserID,
+		Assignment: assigned,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type prDeclineRequest struct {
+	PRId       string               `json:"pull_request_id"`
+	ReviewerID string               `json:"user_id"`
+	Reason     domain.DeclineReason `json:"reason"`
+}
+
+// Decline handles POST /pullRequest/decline.
+func (h *PRHandler) Decline(w http.ResponseWriter, r *http.Request) {
+	var req prDeclineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.PRId == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+
+	h.decline(w, r, service.DeclineReviewInput{
+		PullRequestID: req.PRId,
+		ReviewerID:    req.ReviewerID,
+		Reason:        req.Reason,
+	})
+}
+
+// DeclineV2 handles POST /v2/pull-requests/{id}/decline.
+func (h *PRHandler) DeclineV2(w http.ResponseWriter, r *http.Request) {
+	var req prDeclineRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+
+	h.decline(w, r, service.DeclineReviewInput{
+		PullRequestID: r.PathValue("id"),
+		ReviewerID:    req.ReviewerID,
+		Reason:        req.Reason,
+	})
+}
+
+func (h *PRHandler) decline(w http.ResponseWriter, r *http.Request, input service.DeclineReviewInput) {
+	ctx := r.Context()
+
+	if input.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+	if !input.Reason.Valid() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "reason must be busy, no_context or conflict_of_interest")
+		return
+	}
+
+	pr, assigned, err := h.prService.Decline(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "PR or user not found")
+			return
+		case errors.Is(err, domain.ErrPRMerged):
+			writeError(w, http.StatusConflict, "PR_MERGED", "cannot decline review of merged PR")
+			return
+		case errors.Is(err, domain.ErrNotAssigned):
+			writeError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+			return
+		case errors.Is(err, domain.ErrNoCandidate):
+			writeError(w, http.StatusConflict, "NO_CANDIDATE", "no active replacement candidate in team")
+			return
+		case errors.Is(err, domain.ErrAllAtCapacity):
+			writeError(w, http.StatusConflict, "ALL_AT_CAPACITY", "all candidates reached their open review limit")
+			return
+		case errors.Is(err, domain.ErrMandatoryReviewer):
+			writeError(w, http.StatusConflict, "MANDATORY_REVIEWER", "no other mandatory reviewer can replace this one")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		PR         *domain.PullRequest      `json:"pr"`
+		ReplacedBy string                   `json:"replaced_by"`
+		Assignment *domain.AssignedReviewer `json:"assignment"`
+	}{
+		PR:         pr,
+		ReplacedBy: assigned.UserID,
+		Assignment: assigned,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type prReviewerRequest struct {
+	PRId       string `json:"pull_request_id"`
+	ReviewerID string `json:"user_id"`
+}
+
+// AddReviewer handles POST /pullRequest/addReviewer.
+func (h *PRHandler) AddReviewer(w http.ResponseWriter, r *http.Request) {
+	var req prReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.PRId == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+	if req.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.addReviewer(w, r, service.ReviewerChangeInput{PullRequestID: req.PRId, ReviewerID: req.ReviewerID})
+}
+
+// AddReviewerV2 handles POST /v2/pull-requests/{id}/reviewers.
+func (h *PRHandler) AddReviewerV2(w http.ResponseWriter, r *http.Request) {
+	var req prReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.addReviewer(w, r, service.ReviewerChangeInput{PullRequestID: r.PathValue("id"), ReviewerID: req.ReviewerID})
+}
+
+func (h *PRHandler) addReviewer(w http.ResponseWriter, r *http.Request, input service.ReviewerChangeInput) {
+	ctx := r.Context()
+
+	pr, err := h.prService.AddReviewer(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "PR or user not found")
+			return
+		case errors.Is(err, domain.ErrPRMerged):
+			writeError(w, http.StatusConflict, "PR_MERGED", "cannot change reviewers of merged PR")
+			return
+		case errors.Is(err, domain.ErrReviewerIsAuthor):
+			writeError(w, http.StatusConflict, "REVIEWER_IS_AUTHOR", "author cannot review own PR")
+			return
+		case errors.Is(err, domain.ErrNeverPaired):
+			writeError(w, http.StatusConflict, "NEVER_PAIRED", "team rules forbid this reviewer for the PR author")
+			return
+		case errors.Is(err, domain.ErrReviewerInactive):
+			writeError(w, http.StatusConflict, "REVIEWER_INACTIVE", "reviewer is not active")
+			return
+		case errors.Is(err, domain.ErrAlreadyAssigned):
+			writeError(w, http.StatusConflict, "ALREADY_ASSIGNED", "reviewer is already assigned to this PR")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		PR *domain.PullRequest `json:"pr"`
+	}{
+		PR: pr,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// RemoveReviewer handles POST /pullRequest/removeReviewer.
+func (h *PRHandler) RemoveReviewer(w http.ResponseWriter, r *http.Request) {
+	var req prReviewerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.PRId == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+	if req.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.removeReviewer(w, r, service.ReviewerChangeInput{PullRequestID: req.PRId, ReviewerID: req.ReviewerID})
+}
+
+// RemoveReviewerV2 handles DELETE /v2/pull-requests/{id}/reviewers/{user_id}.
+func (h *PRHandler) RemoveReviewerV2(w http.ResponseWriter, r *http.Request) {
+	h.removeReviewer(w, r, service.ReviewerChangeInput{PullRequestID: r.PathValue("id"), ReviewerID: r.PathValue("user_id")})
+}
+
+func (h *PRHandler) removeReviewer(w http.ResponseWriter, r *http.Request, input service.ReviewerChangeInput) {
+	ctx := r.Context()
+
+	pr, err := h.prService.RemoveReviewer(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "PR not found")
+			return
+		case errors.Is(err, domain.ErrPRMerged):
+			writeError(w, http.StatusConflict, "PR_MERGED", "cannot change reviewers of merged PR")
+			return
+		case errors.Is(err, domain.ErrNotAssigned):
+			writeError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+			return
+		case errors.Is(err, domain.ErrMandatoryReviewer):
+			writeError(w, http.StatusConflict, "MANDATORY_REVIEWER", "mandatory reviewer cannot be removed")
+			return
+		case errors.Is(err, domain.ErrSeniorRequired):
+			writeError(w, http.StatusConflict, "SENIOR_REQUIRED", "team rules require a senior reviewer on this PR")
+			return
+		case errors.Is(err, domain.ErrLastReviewer):
+			writeError(w, http.StatusConflict, "LAST_REVIEWER", "cannot remove the last reviewer of an open PR")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		PR *domain.PullRequest `json:"pr"`
+	}{
+		PR: pr,
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
+
+type prSwapReviewersRequest struct {
+	First  prReviewerRequest `json:"first"`
+	Second prReviewerRequest `json:"second"`
+}
+
+// SwapReviewers handles POST /pullRequest/swapReviewers and POST /v2/pull-requests/swap-reviewers.
+func (h *PRHandler) SwapReviewers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req prSwapReviewersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.First.PRId == "" || req.Second.PRId == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull_request_id is required")
+		return
+	}
+	if req.First.ReviewerID == "" || req.Second.ReviewerID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+	if req.First.PRId == req.Second.PRId {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "pull requests must differ")
+		return
+	}
+
+	first, second, err := h.prService.SwapReviewers(ctx, service.SwapReviewersInput{
+		First:  service.ReviewerChangeInput{PullRequestID: req.First.PRId, ReviewerID: req.First.ReviewerID},
+		Second: service.ReviewerChangeInput{PullRequestID: req.Second.PRId, ReviewerID: req.Second.ReviewerID},
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "PR or user not found")
+			return
+		case errors.Is(err, domain.ErrPRMerged):
+			writeError(w, http.StatusConflict, "PR_MERGED", "cannot change reviewers of merged PR")
+			return
+		case errors.Is(err, domain.ErrNotAssigned):
+			writeError(w, http.StatusConflict, "NOT_ASSIGNED", "reviewer is not assigned to this PR")
+			return
+		case errors.Is(err, domain.ErrMandatoryReviewer):
+			writeError(w, http.StatusConflict, "MANDATORY_REVIEWER", "mandatory reviewer can only be swapped for another mandatory reviewer")
+			return
+		case errors.Is(err, domain.ErrSeniorRequired):
+			writeError(w, http.StatusConflict, "SENIOR_REQUIRED", "team rules require a senior reviewer on this PR")
+			return
+		case errors.Is(err, domain.ErrReviewerIsAuthor):
+			writeError(w, http.StatusConflict, "REVIEWER_IS_AUTHOR", "author cannot review own PR")
+			return
+		case errors.Is(err, domain.ErrNeverPaired):
+			writeError(w, http.StatusConflict, "NEVER_PAIRED", "team rules forbid this reviewer for the PR author")
+			return
+		case errors.Is(err, domain.ErrReviewerInactive):
+			writeError(w, http.StatusConflict, "REVIEWER_INACTIVE", "reviewer is not active")
+			return
+		case errors.Is(err, domain.ErrAlreadyAssigned):
+			writeError(w, http.StatusConflict, "ALREADY_ASSIGNED", "reviewer is already assigned to this PR")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
+	}
+
+	resp := struct {
+		First  *domain.PullRequest `json:"first"`
+		Second *domain.PullRequest `json:"second"`
+	}{
+		First:  first,
+		Second: second,
 	}
 
 	writeJSON(w, http.StatusOK, resp)
@@ -1,8 +1,10 @@
 package handlers
 
 import (
+	"errors"
 	"net/http"
 
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
 	"github.com/CodebyTecs/pr-assign-service/internal/service"
 )
 
@@ -19,8 +21,17 @@ func (h *StatsHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	stats, err := h.statsService.Get(ctx)
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "INTERNAL", "internal error")
-		return
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL", err)
+			return
+		}
 	}
 
 	writeJSON(w, http.StatusOK, stats)
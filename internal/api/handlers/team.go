@@ -3,12 +3,21 @@ package handlers
 import (
 	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
+	"strconv"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
 	"github.com/CodebyTecs/pr-assign-service/internal/service"
+	"github.com/CodebyTecs/pr-assign-service/internal/teamimport"
 )
 
+// maxImportSize bounds the body of a bulk team import.
+const maxImportSize = 10 << 20
+
+// maxCodeownersSize bounds an uploaded CODEOWNERS file.
+const maxCodeownersSize = 1 << 20
+
 type TeamHandler struct {
 	teamService service.TeamService
 }
@@ -20,22 +29,39 @@ func NewTeamHandler(teamService service.TeamService) *TeamHandler {
 }
 
 func (h *TeamHandler) Get(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	teamName := r.URL.Query().Get("team_name")
 	if teamName == "" {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
 		return
 	}
 
+	h.get(w, r, teamName)
+}
+
+// GetV2 handles GET /v2/teams/{name}.
+func (h *TeamHandler) GetV2(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, r.PathValue("name"))
+}
+
+func (h *TeamHandler) get(w http.ResponseWriter, r *http.Request, teamName string) {
+	ctx := r.Context()
+
 	team, err := h.teamService.Get(ctx, teamName)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
-			writeError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "team or partner team not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
 			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
-		return
 	}
 
 	writeJSON(w, http.StatusOK, team)
@@ -53,10 +79,15 @@ func (h *TeamHandler) Add(w http.ResponseWriter, r *http.Request) {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
 		return
 	}
+	if req.CapacityPolicy != "" && !req.CapacityPolicy.Valid() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "capacity_policy must be reject or least_loaded")
+		return
+	}
 
 	input := service.CreateTeamInput{
-		Name:    req.Name,
-		Members: make([]service.CreateTeamMemberInput, 0, len(req.Members)),
+		Name:           req.Name,
+		CapacityPolicy: req.CapacityPolicy,
+		Members:        make([]service.CreateTeamMemberInput, 0, len(req.Members)),
 	}
 
 	for _, member := range req.Members {
@@ -69,12 +100,23 @@ func (h *TeamHandler) Add(w http.ResponseWriter, r *http.Request) {
 
 	team, err := h.teamService.Create(ctx, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrTeamExists) {
+		switch {
+		case errors.Is(err, domain.ErrTeamExists):
 			writeError(w, http.StatusBadRequest, "TEAM_EXISTS", "team already exists")
 			return
+		case errors.Is(err, domain.ErrUserDeleted):
+			writeError(w, http.StatusConflict, "USER_DELETED", "team member has been offboarded")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
-		return
 	}
 
 	resp := struct {
@@ -85,3 +127,396 @@ func (h *TeamHandler) Add(w http.ResponseWriter, r *http.Request) {
 
 	writeJSON(w, http.StatusCreated, resp)
 }
+
+type setCapacityPolicyRequest struct {
+	TeamName       string                `json:"team_name"`
+	CapacityPolicy domain.CapacityPolicy `json:"capacity_policy"`
+}
+
+func (h *TeamHandler) SetCapacityPolicy(w http.ResponseWriter, r *http.Request) {
+	var req setCapacityPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	h.update(w, r, service.UpdateTeamInput{Name: req.TeamName, CapacityPolicy: &req.CapacityPolicy})
+}
+
+type setPartnerTeamsRequest struct {
+	TeamName     string   `json:"team_name"`
+	PartnerTeams []string `json:"partner_teams"`
+}
+
+func (h *TeamHandler) SetPartnerTeams(w http.ResponseWriter, r *http.Request) {
+	var req setPartnerTeamsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+	if req.PartnerTeams == nil {
+		req.PartnerTeams = []string{}
+	}
+
+	h.update(w, r, service.UpdateTeamInput{Name: req.TeamName, PartnerTeams: &req.PartnerTeams})
+}
+
+type setMentoringRequest struct {
+	TeamName  string `json:"team_name"`
+	Mentoring *bool  `json:"mentoring"`
+}
+
+// SetMentoring handles POST /team/setMentoring.
+func (h *TeamHandler) SetMentoring(w http.ResponseWriter, r *http.Request) {
+	var req setMentoringRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+	if req.Mentoring == nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "mentoring is required")
+		return
+	}
+
+	h.update(w, r, service.UpdateTeamInput{Name: req.TeamName, Mentoring: req.Mentoring})
+}
+
+type patchTeamRequest struct {
+	CapacityPolicy *domain.CapacityPolicy `json:"capacity_policy"`
+	PartnerTeams   *[]string              `json:"partner_teams"`
+	Mentoring      *bool                  `json:"mentoring"`
+}
+
+// PatchV2 handles PATCH /v2/teams/{name}.
+func (h *TeamHandler) PatchV2(w http.ResponseWriter, r *http.Request) {
+	var req patchTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.CapacityPolicy == nil && req.PartnerTeams == nil && req.Mentoring == nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "capacity_policy, partner_teams or mentoring is required")
+		return
+	}
+
+	h.update(w, r, service.UpdateTeamInput{
+		Name:           r.PathValue("name"),
+		CapacityPolicy: req.CapacityPolicy,
+		PartnerTeams:   req.PartnerTeams,
+		Mentoring:      req.Mentoring,
+	})
+}
+
+func (h *TeamHandler) update(w http.ResponseWriter, r *http.Request, input service.UpdateTeamInput) {
+	ctx := r.Context()
+
+	if input.CapacityPolicy != nil && !input.CapacityPolicy.Valid() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "capacity_policy must be reject or least_loaded")
+		return
+	}
+	if input.PartnerTeams != nil {
+		seen := make(map[string]bool, len(*input.PartnerTeams))
+		for _, partner := range *input.PartnerTeams {
+			if partner == "" || partner == input.Name || seen[partner] {
+				writeError(w, http.StatusBadRequest, "BAD_REQUEST", "partner_teams must be distinct other teams")
+				return
+			}
+			seen[partner] = true
+		}
+	}
+
+	team, err := h.teamService.Update(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, team)
+}
+
+func (h *TeamHandler) GetCodeowners(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	h.getCodeowners(w, r, teamName)
+}
+
+// GetCodeownersV2 handles GET /v2/teams/{name}/codeowners.
+func (h *TeamHandler) GetCodeownersV2(w http.ResponseWriter, r *http.Request) {
+	h.getCodeowners(w, r, r.PathValue("name"))
+}
+
+func (h *TeamHandler) getCodeowners(w http.ResponseWriter, r *http.Request, teamName string) {
+	ctx := r.Context()
+
+	file, err := h.teamService.GetCodeowners(ctx, teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "codeowners not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, file)
+}
+
+type setCodeownersRequest struct {
+	TeamName string `json:"team_name"`
+	Content  string `json:"content"`
+}
+
+func (h *TeamHandler) SetCodeowners(w http.ResponseWriter, r *http.Request) {
+	var req setCodeownersRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCodeownersSize)).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	h.setCodeowners(w, r, req.TeamName, req.Content)
+}
+
+// PutCodeownersV2 handles PUT /v2/teams/{name}/codeowners; the body is the file itself.
+func (h *TeamHandler) PutCodeownersV2(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCodeownersSize))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "can't read body")
+		return
+	}
+
+	h.setCodeowners(w, r, r.PathValue("name"), string(body))
+}
+
+func (h *TeamHandler) setCodeowners(w http.ResponseWriter, r *http.Request, teamName, content string) {
+	ctx := r.Context()
+
+	file, rowErrs, err := h.teamService.SetCodeowners(ctx, teamName, content)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCodeowners):
+			var resp struct {
+				errorBody
+				Rows []domain.ImportRowError `json:"rows"`
+			}
+			resp.Error.Code = "CODEOWNERS_INVALID"
+			resp.Error.Message = "codeowners file is invalid"
+			resp.Rows = rowErrs
+			writeJSON(w, http.StatusUnprocessableEntity, resp)
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, file)
+}
+
+func (h *TeamHandler) GetReviewRules(w http.ResponseWriter, r *http.Request) {
+	teamName := r.URL.Query().Get("team_name")
+	if teamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	h.getReviewRules(w, r, teamName)
+}
+
+// GetReviewRulesV2 handles GET /v2/teams/{name}/review-rules.
+func (h *TeamHandler) GetReviewRulesV2(w http.ResponseWriter, r *http.Request) {
+	h.getReviewRules(w, r, r.PathValue("name"))
+}
+
+func (h *TeamHandler) getReviewRules(w http.ResponseWriter, r *http.Request, teamName string) {
+	ctx := r.Context()
+
+	rules, err := h.teamService.GetReviewRules(ctx, teamName)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (h *TeamHandler) SetReviewRules(w http.ResponseWriter, r *http.Request) {
+	var req domain.ReviewRules
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.TeamName == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "team_name is required")
+		return
+	}
+
+	h.setReviewRules(w, r, req)
+}
+
+// PutReviewRulesV2 handles PUT /v2/teams/{name}/review-rules.
+func (h *TeamHandler) PutReviewRulesV2(w http.ResponseWriter, r *http.Request) {
+	var req domain.ReviewRules
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	req.TeamName = r.PathValue("name")
+
+	h.setReviewRules(w, r, req)
+}
+
+func (h *TeamHandler) setReviewRules(w http.ResponseWriter, r *http.Request, req domain.ReviewRules) {
+	ctx := r.Context()
+
+	rules, rowErrs, err := h.teamService.SetReviewRules(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidReviewRules):
+			var resp struct {
+				errorBody
+				Rows []domain.ImportRowError `json:"rows"`
+			}
+			resp.Error.Code = "REVIEW_RULES_INVALID"
+			resp.Error.Message = "review rules are invalid"
+			resp.Rows = rowErrs
+			writeJSON(w, http.StatusUnprocessableEntity, resp)
+			return
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "team not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+// Import handles POST /team/import. The document format is taken from the
+// format query parameter or, if absent, from Content-Type.
+func (h *TeamHandler) Import(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	formatName := r.URL.Query().Get("format")
+	if formatName == "" {
+		formatName = r.Header.Get("Content-Type")
+	}
+	format, err := teamimport.ParseFormat(formatName)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "format must be yaml or csv")
+		return
+	}
+
+	dryRun := false
+	if v := r.URL.Query().Get("dry_run"); v != "" {
+		dryRun, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "dry_run must be true or false")
+			return
+		}
+	}
+
+	result, err := h.teamService.Import(ctx, service.ImportTeamsInput{
+		Format: format,
+		Body:   http.MaxBytesReader(w, r.Body, maxImportSize),
+		DryRun: dryRun,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidImport):
+			var resp struct {
+				errorBody
+				Rows []domain.ImportRowError `json:"rows"`
+			}
+			resp.Error.Code = "IMPORT_INVALID"
+			resp.Error.Message = "import document is invalid"
+			resp.Rows = result.Errors
+			writeJSON(w, http.StatusUnprocessableEntity, resp)
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	status := http.StatusCreated
+	if result.DryRun {
+		status = http.StatusOK
+	}
+
+	writeJSON(w, status, result)
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"errors"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
 	"github.com/CodebyTecs/pr-assign-service/internal/service"
@@ -31,8 +34,6 @@ type userResponse struct {
 }
 
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	var req setIsActiveRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
@@ -43,14 +44,114 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userService.UpdateActivity(ctx, req.UserID, req.IsActive)
+	h.patch(w, r, service.PatchUserInput{UserID: req.UserID, IsActive: &req.IsActive})
+}
+
+type setMaxOpenReviewsRequest struct {
+	UserID         string `json:"user_id"`
+	MaxOpenReviews *int   `json:"max_open_reviews"`
+}
+
+// SetMaxOpenReviews handles POST /users/setMaxOpenReviews; zero removes the limit.
+func (h *UserHandler) SetMaxOpenReviews(w http.ResponseWriter, r *http.Request) {
+	var req setMaxOpenReviewsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+	if req.MaxOpenReviews == nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "max_open_reviews is required")
+		return
+	}
+
+	h.patch(w, r, service.PatchUserInput{UserID: req.UserID, MaxOpenReviews: req.MaxOpenReviews})
+}
+
+type setSeniorityRequest struct {
+	UserID    string            `json:"user_id"`
+	Seniority *domain.Seniority `json:"seniority"`
+}
+
+// SetSeniority handles POST /users/setSeniority.
+func (h *UserHandler) SetSeniority(w http.ResponseWriter, r *http.Request) {
+	var req setSeniorityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+	if req.Seniority == nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "seniority is required")
+		return
+	}
+
+	h.patch(w, r, service.PatchUserInput{UserID: req.UserID, Seniority: req.Seniority})
+}
+
+type patchUserRequest struct {
+	IsActive       *bool             `json:"is_active"`
+	MaxOpenReviews *int              `json:"max_open_reviews"`
+	Seniority      *domain.Seniority `json:"seniority"`
+}
+
+// PatchV2 handles PATCH /v2/users/{id}.
+func (h *UserHandler) PatchV2(w http.ResponseWriter, r *http.Request) {
+	var req patchUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.IsActive == nil && req.MaxOpenReviews == nil && req.Seniority == nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "is_active, max_open_reviews or seniority is required")
+		return
+	}
+
+	h.patch(w, r, service.PatchUserInput{
+		UserID:         r.PathValue("id"),
+		IsActive:       req.IsActive,
+		MaxOpenReviews: req.MaxOpenReviews,
+		Seniority:      req.Seniority,
+	})
+}
+
+func (h *UserHandler) patch(w http.ResponseWriter, r *http.Request, input service.PatchUserInput) {
+	ctx := r.Context()
+
+	if input.MaxOpenReviews != nil && *input.MaxOpenReviews < 0 {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "max_open_reviews must not be negative")
+		return
+	}
+	if input.Seniority != nil && !input.Seniority.Valid() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "seniority must be junior, middle or senior")
+		return
+	}
+
+	user, err := h.userService.Patch(ctx, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
 			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
 			return
+		case errors.Is(err, domain.ErrUserDeleted):
+			writeError(w, http.StatusConflict, "USER_DELETED", "user has been offboarded")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
-		return
 	}
 
 	writeJSON(w, http.StatusOK, userResponse{User: user})
@@ -59,31 +160,545 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 type userReviewResponse struct {
 	UserID      string                    `json:"user_id"`
 	PullRequest []domain.PullRequestShort `json:"pull_requests"`
+	NextCursor  string                    `json:"next_cursor,omitempty"`
 }
 
 func (h *UserHandler) GetReview(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	userID := r.URL.Query().Get("user_id")
 	if userID == "" {
 		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
 		return
 	}
 
-	prs, err := h.prService.ListByReviewer(ctx, userID)
+	// Clients of v1 predate pagination and get every review unless they ask for a page.
+	h.listReviews(w, r, userID, true)
+}
+
+// ReviewsV2 handles GET /v2/users/{id}/reviews.
+func (h *UserHandler) ReviewsV2(w http.ResponseWriter, r *http.Request) {
+	h.listReviews(w, r, r.PathValue("id"), false)
+}
+
+func (h *UserHandler) listReviews(w http.ResponseWriter, r *http.Request, userID string, unpaged bool) {
+	ctx := r.Context()
+
+	input, err := parseListPRsQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+	input.Unpaged = unpaged
+
+	page, err := h.prService.ListByReviewer(ctx, userID, input)
 	if err != nil {
-		if errors.Is(err, domain.ErrNotFound) {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
 			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
 			return
+		case errors.Is(err, domain.ErrInvalidCursor):
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid cursor")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
 		}
-		writeError(w, http.StatusInternalServerError, "INTERNAL_ERROR", "internal error")
-		return
 	}
 
 	resp := userReviewResponse{
 		UserID:      userID,
-		PullRequest: prs,
+		PullRequest: page.PullRequests,
+		NextCursor:  page.NextCursor,
 	}
 
 	writeJSON(w, http.StatusOK, resp)
 }
+
+func (h *UserHandler) Get(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.get(w, r, userID)
+}
+
+// GetV2 handles GET /v2/users/{id}.
+func (h *UserHandler) GetV2(w http.ResponseWriter, r *http.Request) {
+	h.get(w, r, r.PathValue("id"))
+}
+
+func (h *UserHandler) get(w http.ResponseWriter, r *http.Request, userID string) {
+	ctx := r.Context()
+
+	user, err := h.userService.Get(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, userResponse{User: user})
+}
+
+// List handles GET /users/list and GET /v2/users.
+func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	input, err := parseListUsersQuery(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+
+	page, err := h.userService.List(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrInvalidCursor):
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid cursor")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+type offboardRequest struct {
+	UserID    string `json:"user_id"`
+	Anonymize bool   `json:"anonymize"`
+}
+
+func (h *UserHandler) Offboard(w http.ResponseWriter, r *http.Request) {
+	var req offboardRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.offboard(w, r, service.OffboardUserInput{UserID: req.UserID, Anonymize: req.Anonymize})
+}
+
+// DeleteV2 handles DELETE /v2/users/{id}?anonymize=true.
+func (h *UserHandler) DeleteV2(w http.ResponseWriter, r *http.Request) {
+	anonymize := false
+	if v := r.URL.Query().Get("anonymize"); v != "" {
+		var err error
+		anonymize, err = strconv.ParseBool(v)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "anonymize must be true or false")
+			return
+		}
+	}
+
+	h.offboard(w, r, service.OffboardUserInput{UserID: r.PathValue("id"), Anonymize: anonymize})
+}
+
+func (h *UserHandler) offboard(w http.ResponseWriter, r *http.Request, input service.OffboardUserInput) {
+	ctx := r.Context()
+
+	result, err := h.userService.Offboard(ctx, input)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		case errors.Is(err, domain.ErrUserDeleted):
+			writeError(w, http.StatusConflict, "USER_DELETED", "user has been offboarded")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+type addUnavailabilityRequest struct {
+	UserID   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+func (h *UserHandler) AddUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req addUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.addUnavailability(w, r, req)
+}
+
+// AddUnavailabilityV2 handles POST /v2/users/{id}/unavailability.
+func (h *UserHandler) AddUnavailabilityV2(w http.ResponseWriter, r *http.Request) {
+	var req addUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	req.UserID = r.PathValue("id")
+
+	h.addUnavailability(w, r, req)
+}
+
+func (h *UserHandler) addUnavailability(w http.ResponseWriter, r *http.Request, req addUnavailabilityRequest) {
+	ctx := r.Context()
+
+	if req.StartsAt.IsZero() || req.EndsAt.IsZero() {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "starts_at and ends_at are required")
+		return
+	}
+	if !req.EndsAt.After(req.StartsAt) {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "ends_at must be after starts_at")
+		return
+	}
+	if !req.EndsAt.After(time.Now()) {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "ends_at must be in the future")
+		return
+	}
+
+	window, err := h.userService.AddUnavailability(ctx, service.AddUnavailabilityInput{
+		UserID:   req.UserID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		case errors.Is(err, domain.ErrUserDeleted):
+			writeError(w, http.StatusConflict, "USER_DELETED", "user has been offboarded")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, struct {
+		Unavailability *domain.Unavailability `json:"unavailability"`
+	}{
+		Unavailability: window,
+	})
+}
+
+type unavailabilityListResponse struct {
+	UserID         string                  `json:"user_id"`
+	Unavailability []domain.Unavailability `json:"unavailability"`
+}
+
+func (h *UserHandler) ListUnavailability(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.listUnavailability(w, r, userID)
+}
+
+// ListUnavailabilityV2 handles GET /v2/users/{id}/unavailability.
+func (h *UserHandler) ListUnavailabilityV2(w http.ResponseWriter, r *http.Request) {
+	h.listUnavailability(w, r, r.PathValue("id"))
+}
+
+func (h *UserHandler) listUnavailability(w http.ResponseWriter, r *http.Request, userID string) {
+	ctx := r.Context()
+
+	windows, err := h.userService.ListUnavailability(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, unavailabilityListResponse{UserID: userID, Unavailability: windows})
+}
+
+type removeUnavailabilityRequest struct {
+	UserID           string `json:"user_id"`
+	UnavailabilityID int64  `json:"unavailability_id"`
+}
+
+func (h *UserHandler) RemoveUnavailability(w http.ResponseWriter, r *http.Request) {
+	var req removeUnavailabilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.UserID == "" || req.UnavailabilityID == 0 {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id and unavailability_id are required")
+		return
+	}
+
+	h.removeUnavailability(w, r, req.UserID, req.UnavailabilityID)
+}
+
+// RemoveUnavailabilityV2 handles DELETE /v2/users/{id}/unavailability/{unavailability_id}.
+func (h *UserHandler) RemoveUnavailabilityV2(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(r.PathValue("unavailability_id"), 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unavailability not found")
+		return
+	}
+
+	h.removeUnavailability(w, r, r.PathValue("id"), id)
+}
+
+func (h *UserHandler) removeUnavailability(w http.ResponseWriter, r *http.Request, userID string, id int64) {
+	ctx := r.Context()
+
+	err := h.userService.RemoveUnavailability(ctx, userID, id)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "unavailability not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		UserID           string `json:"user_id"`
+		UnavailabilityID int64  `json:"unavailability_id"`
+		Removed          bool   `json:"removed"`
+	}{
+		UserID:           userID,
+		UnavailabilityID: id,
+		Removed:          true,
+	})
+}
+
+func (h *UserHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	tags, err := h.userService.ListTags(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Tags []domain.Tag `json:"tags"`
+	}{
+		Tags: tags,
+	})
+}
+
+// CreateTag handles POST /tags/add and POST /v2/tags.
+func (h *UserHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var req domain.Tag
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	req.Name = strings.ToLower(strings.TrimSpace(req.Name))
+	if !domain.ValidTagName(req.Name) {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "name must be 1-32 lowercase letters, digits or ._+#- characters")
+		return
+	}
+
+	tag, err := h.userService.CreateTag(ctx, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrTagExists):
+			writeError(w, http.StatusConflict, "TAG_EXISTS", "tag already exists")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusCreated, struct {
+		Tag *domain.Tag `json:"tag"`
+	}{
+		Tag: tag,
+	})
+}
+
+func (h *UserHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	userID := r.URL.Query().Get("user_id")
+	if userID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.getTags(w, r, userID)
+}
+
+// GetTagsV2 handles GET /v2/users/{id}/tags.
+func (h *UserHandler) GetTagsV2(w http.ResponseWriter, r *http.Request) {
+	h.getTags(w, r, r.PathValue("id"))
+}
+
+func (h *UserHandler) getTags(w http.ResponseWriter, r *http.Request, userID string) {
+	ctx := r.Context()
+
+	tags, err := h.userService.GetTags(ctx, userID)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, tags)
+}
+
+type setTagsRequest struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
+
+func (h *UserHandler) SetTags(w http.ResponseWriter, r *http.Request) {
+	var req setTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	if req.UserID == "" {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "user_id is required")
+		return
+	}
+
+	h.setTags(w, r, req)
+}
+
+// SetTagsV2 handles PUT /v2/users/{id}/tags.
+func (h *UserHandler) SetTagsV2(w http.ResponseWriter, r *http.Request) {
+	var req setTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", "invalid json")
+		return
+	}
+	req.UserID = r.PathValue("id")
+
+	h.setTags(w, r, req)
+}
+
+func (h *UserHandler) setTags(w http.ResponseWriter, r *http.Request, req setTagsRequest) {
+	ctx := r.Context()
+
+	tags, err := h.userService.SetTags(ctx, req.UserID, req.Tags)
+	if err != nil {
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "user not found")
+			return
+		case errors.Is(err, domain.ErrUnknownTag):
+			writeError(w, http.StatusBadRequest, "UNKNOWN_TAG", err.Error())
+			return
+		case errors.Is(err, domain.ErrUserDeleted):
+			writeError(w, http.StatusConflict, "USER_DELETED", "user has been offboarded")
+			return
+		case errors.Is(err, domain.ErrUnauthorized):
+			writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "authentication required")
+			return
+		case errors.Is(err, domain.ErrForbidden):
+			writeError(w, http.StatusForbidden, "FORBIDDEN", "operation not permitted")
+			return
+		default:
+			writeInternalError(w, r, "INTERNAL_ERROR", err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, tags)
+}
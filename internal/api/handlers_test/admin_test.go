@@ -0,0 +1,77 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testArchive = `{"type":"header","version":1,"exported_at":"2025-11-01T10:00:00Z"}
+{"type":"team","team":{"team_name":"backend"}}
+{"type":"user","user":{"user_id":"u1","username":"Alice","team_name":"backend","is_active":true}}
+{"type":"pull_request","pull_request":{"pull_request_id":"pr-1","pull_request_name":"Add search","author_id":"u1","status":"OPEN","assigned_reviewers":[],"createdAt":"2025-11-01T09:00:00Z"}}
+`
+
+func TestAdminExport(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/admin/export", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/x-ndjson", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment")
+
+	lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"type":"header","version":2`)
+	assert.Contains(t, lines[1], `"team":{"team_name":"backend"}`)
+}
+
+func TestAdminImportMerge(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/admin/import?mode=merge", strings.NewReader(testArchive))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pull_requests":{"created":1,"skipped":0}`)
+}
+
+func TestAdminImportRestoreNotEmpty(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/v2/admin/import", strings.NewReader(testArchive))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"NOT_EMPTY"`)
+}
+
+func TestAdminImportInvalidArchive(t *testing.T) {
+	r := newTestRouter()
+	body := testArchive + "{\"type\":\"comment\"}\n"
+	req := httptest.NewRequest("POST", "/admin/import?mode=merge", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"row":5`)
+}
+
+func TestAdminImportBadMode(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/admin/import?mode=overwrite", strings.NewReader(testArchive))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,93 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
+)
+
+func newAuthTestRouter() http.Handler {
+	return newTestRouterWith(middleware.Authenticate(&mockAuthService{}, "/healthz", "/readyz"))
+}
+
+func TestAuthRejectsMissingToken(t *testing.T) {
+	r := newAuthTestRouter()
+	req := httptest.NewRequest("GET", "/stats", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"UNAUTHORIZED"`)
+	assert.NotEmpty(t, w.Header().Get("WWW-Authenticate"))
+}
+
+func TestAuthRejectsUnknownToken(t *testing.T) {
+	r := newAuthTestRouter()
+	req := httptest.NewRequest("GET", "/stats", nil)
+	req.Header.Set("Authorization", "Bearer nope")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestAuthAcceptsValidToken(t *testing.T) {
+	r := newAuthTestRouter()
+	req := httptest.NewRequest("GET", "/stats", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestAuthSkipsHealthz(t *testing.T) {
+	r := newAuthTestRouter()
+	req := httptest.NewRequest("GET", "/healthz", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestCreateToken(t *testing.T) {
+	r := newTestRouter()
+	body := `{"name": "ci", "role": "bot"}`
+	req := httptest.NewRequest("POST", "/auth/tokens/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"token":"prs_new"`)
+}
+
+func TestCreateTokenRequiresTeamForLead(t *testing.T) {
+	r := newTestRouter()
+	body := `{"name": "lead", "role": "team_lead"}`
+	req := httptest.NewRequest("POST", "/auth/tokens/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestRevokeToken(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/auth/tokens/revoke", strings.NewReader(`{"token_id": "t1"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
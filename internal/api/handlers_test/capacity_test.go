@@ -0,0 +1,96 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetMaxOpenReviews(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/setMaxOpenReviews", strings.NewReader(`{"user_id": "u1", "max_open_reviews": 3}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"max_open_reviews":3`)
+}
+
+func TestSetMaxOpenReviewsNegative(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/setMaxOpenReviews", strings.NewReader(`{"user_id": "u1", "max_open_reviews": -1}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPatchUserV2MaxOpenReviews(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/users/u1", strings.NewReader(`{"max_open_reviews": 2}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"max_open_reviews":2`)
+}
+
+func TestSetCapacityPolicy(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/setCapacityPolicy", strings.NewReader(`{"team_name": "backend", "capacity_policy": "least_loaded"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"capacity_policy":"least_loaded"`)
+}
+
+func TestSetCapacityPolicyInvalid(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/teams/backend", strings.NewReader(`{"capacity_policy": "random"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestSetCapacityPolicyTeamNotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/teams/missing", strings.NewReader(`{"capacity_policy": "reject"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreatePRAllAtCapacity(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-9", "pull_request_name": "Fix", "author_id": "busy"}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"ALL_AT_CAPACITY"`)
+}
+
+func TestReassignAllAtCapacity(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", strings.NewReader(`{"pull_request_id": "pr-1", "old_user_id": "busy"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"ALL_AT_CAPACITY"`)
+}
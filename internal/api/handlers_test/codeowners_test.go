@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetCodeowners(t *testing.T) {
+	r := newTestRouter()
+	body := `{"team_name": "backend", "content": "* backend\n/internal/auth/ u1\n"}`
+	req := httptest.NewRequest("POST", "/team/setCodeowners", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"team_name":"backend"`)
+}
+
+func TestPutCodeownersV2Invalid(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PUT", "/v2/teams/backend/codeowners", strings.NewReader("* backend\n/docs/\n*.sql ghost\n"))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"CODEOWNERS_INVALID"`)
+	assert.Contains(t, w.Body.String(), `{"row":2,"field":"owners","message":"pattern has no owners"}`)
+	assert.Contains(t, w.Body.String(), `"row":3`)
+}
+
+func TestGetCodeowners(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/teams/backend/codeowners", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"content":"* u1\n"`)
+}
+
+func TestGetCodeownersNotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/team/codeowners?team_name=frontend", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreatePRWithChangedFiles(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-7", "pull_request_name": "Auth", "author_id": "u1", "changed_files": ["internal/auth/token.go"]}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `{"user_id":"u2","source":"codeowners","rule":{"line":1,"pattern":"*","owners":["u2"]}}`)
+	assert.Contains(t, w.Body.String(), `{"user_id":"u3","source":"team"}`)
+}
@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListDecisions(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/pullRequest/decisions?pull_request_id=pr-1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"seed":42`)
+	assert.Contains(t, w.Body.String(), `"candidates":["u2","u3","u4"]`)
+	assert.Contains(t, w.Body.String(), `{"user_id":"u5","reason":"at_capacity"}`)
+	assert.Contains(t, w.Body.String(), `"selected":["u3","u2"]`)
+}
+
+func TestListDecisionsV2(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/pull-requests/pr-1/decisions", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"kind":"create"`)
+}
+
+func TestListDecisionsNotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/pull-requests/missing/decisions", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestListDecisionsRequiresID(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/pullRequest/decisions", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
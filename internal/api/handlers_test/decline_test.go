@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeclineReview(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "user_id": "u2", "reason": "busy"}`
+	req := httptest.NewRequest("POST", "/pullRequest/decline", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"replaced_by":"id-new"`)
+}
+
+func TestDeclineReviewV2(t *testing.T) {
+	r := newTestRouter()
+	body := `{"user_id": "u2", "reason": "conflict_of_interest"}`
+	req := httptest.NewRequest("POST", "/v2/pull-requests/pr-1/decline", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pull_request_id":"pr-1"`)
+}
+
+func TestDeclineReviewErrors(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		code int
+	}{
+		"no user":        {`{"pull_request_id": "pr-1", "reason": "busy"}`, http.StatusBadRequest},
+		"no reason":      {`{"pull_request_id": "pr-1", "user_id": "u2"}`, http.StatusBadRequest},
+		"unknown reason": {`{"pull_request_id": "pr-1", "user_id": "u2", "reason": "bored"}`, http.StatusBadRequest},
+		"not assigned":   {`{"pull_request_id": "pr-1", "user_id": "u9", "reason": "busy"}`, http.StatusConflict},
+		"someone else":   {`{"pull_request_id": "pr-1", "user_id": "other", "reason": "no_context"}`, http.StatusForbidden},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/pullRequest/decline", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
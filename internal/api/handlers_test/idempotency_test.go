@@ -0,0 +1,147 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*domain.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: map[string]*domain.IdempotencyRecord{}}
+}
+
+func (s *memoryIdempotencyStore) Reserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (*domain.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if rec, ok := s.records[scope+"|"+key]; ok && rec.ExpiresAt.After(time.Now()) {
+		return rec, false, nil
+	}
+	s.records[scope+"|"+key] = &domain.IdempotencyRecord{
+		Scope:       scope,
+		Key:         key,
+		RequestHash: requestHash,
+		ExpiresAt:   time.Now().Add(ttl),
+	}
+	return nil, true, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.records[scope+"|"+key]
+	rec.StatusCode = status
+	rec.ContentType = contentType
+	rec.ResponseBody = body
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(ctx context.Context, scope, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, scope+"|"+key)
+	return nil
+}
+
+func newIdempotencyRequest(key, body string) *http.Request {
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(middleware.IdempotencyKeyHeader, key)
+	return req
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	r := newTestRouterWith(middleware.Idempotency(newMemoryIdempotencyStore(), time.Hour))
+	body := `{"pull_request_id": "1", "pull_request_name": "test-pr", "author_id": "1"}`
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, newIdempotencyRequest("key-1", body))
+	assert.Equal(t, http.StatusCreated, first.Code)
+	assert.Empty(t, first.Header().Get(middleware.IdempotencyReplayedHeader))
+
+	retry := httptest.NewRecorder()
+	r.ServeHTTP(retry, newIdempotencyRequest("key-1", body))
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, "true", retry.Header().Get(middleware.IdempotencyReplayedHeader))
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+}
+
+func TestIdempotencyRejectsDifferentBody(t *testing.T) {
+	r := newTestRouterWith(middleware.Idempotency(newMemoryIdempotencyStore(), time.Hour))
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, newIdempotencyRequest("key-1", `{"pull_request_id": "1", "pull_request_name": "a", "author_id": "1"}`))
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	other := httptest.NewRecorder()
+	r.ServeHTTP(other, newIdempotencyRequest("key-1", `{"pull_request_id": "2", "pull_request_name": "b", "author_id": "1"}`))
+	assert.Equal(t, http.StatusUnprocessableEntity, other.Code)
+	assert.Contains(t, other.Body.String(), `"code":"IDEMPOTENCY_KEY_REUSED"`)
+}
+
+func TestIdempotencyRejectsConcurrentRetry(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	r := newTestRouterWith(middleware.Idempotency(store, time.Hour))
+	body := `{"pull_request_id": "1", "pull_request_name": "a", "author_id": "1"}`
+
+	r.ServeHTTP(httptest.NewRecorder(), newIdempotencyRequest("key-1", body))
+	// Pretend the first request is still running by dropping its stored response.
+	store.records["anonymous|key-1"].StatusCode = 0
+
+	retry := httptest.NewRecorder()
+	r.ServeHTTP(retry, newIdempotencyRequest("key-1", body))
+	assert.Equal(t, http.StatusConflict, retry.Code)
+}
+
+func TestIdempotencyIgnoresRequestsWithoutKey(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	r := newTestRouterWith(middleware.Idempotency(store, time.Hour))
+
+	req := httptest.NewRequest("POST", "/pullRequest/merge", strings.NewReader(`{"pull_request_id": "1"}`))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotencySkipsExemptPaths(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	r := newTestRouterWith(middleware.Idempotency(store, time.Hour, "/admin/import"))
+
+	req := httptest.NewRequest("POST", "/admin/import", strings.NewReader(strings.Repeat(" ", 2<<20)))
+	req.Header.Set(middleware.IdempotencyKeyHeader, "key-1")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.NotEqual(t, http.StatusRequestEntityTooLarge, w.Code)
+	assert.Empty(t, store.records)
+}
+
+func TestIdempotencyReleasesKeyWhenHandlerPanics(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	h := middleware.Idempotency(store, time.Hour)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}))
+
+	assert.PanicsWithValue(t, "boom", func() {
+		h.ServeHTTP(httptest.NewRecorder(), newIdempotencyRequest("key-1", `{}`))
+	})
+	assert.Empty(t, store.records)
+}
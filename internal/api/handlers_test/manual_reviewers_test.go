@@ -0,0 +1,107 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddReviewer(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "user_id": "u3"}`
+	req := httptest.NewRequest("POST", "/pullRequest/addReviewer", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"assigned_reviewers":["u2","u3"]`)
+}
+
+func TestAddReviewerErrors(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		code int
+	}{
+		"no user":          {`{"pull_request_id": "pr-1"}`, http.StatusBadRequest},
+		"unknown user":     {`{"pull_request_id": "pr-1", "user_id": "missing"}`, http.StatusNotFound},
+		"author":           {`{"pull_request_id": "pr-1", "user_id": "u1"}`, http.StatusConflict},
+		"inactive":         {`{"pull_request_id": "pr-1", "user_id": "idle"}`, http.StatusConflict},
+		"already assigned": {`{"pull_request_id": "pr-1", "user_id": "u2"}`, http.StatusConflict},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/v2/pull-requests/pr-1/reviewers", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
+
+func TestRemoveReviewer(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("DELETE", "/v2/pull-requests/pr-1/reviewers/u2", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"assigned_reviewers":[]`)
+}
+
+func TestRemoveReviewerNotAssigned(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "user_id": "u9"}`
+	req := httptest.NewRequest("POST", "/pullRequest/removeReviewer", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "NOT_ASSIGNED")
+}
+
+func TestSwapReviewers(t *testing.T) {
+	r := newTestRouter()
+	body := `{"first": {"pull_request_id": "pr-1", "user_id": "u3"}, "second": {"pull_request_id": "pr-2", "user_id": "u4"}}`
+	req := httptest.NewRequest("POST", "/v2/pull-requests/swap-reviewers", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"first":{"pull_request_id":"pr-1"`)
+	assert.Contains(t, w.Body.String(), `"assigned_reviewers":["u4"]`)
+}
+
+func TestSwapReviewersErrors(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		code int
+	}{
+		"same PR":  {`{"first": {"pull_request_id": "pr-1", "user_id": "u3"}, "second": {"pull_request_id": "pr-1", "user_id": "u4"}}`, http.StatusBadRequest},
+		"no user":  {`{"first": {"pull_request_id": "pr-1"}, "second": {"pull_request_id": "pr-2", "user_id": "u4"}}`, http.StatusBadRequest},
+		"author":   {`{"first": {"pull_request_id": "pr-1", "user_id": "u1"}, "second": {"pull_request_id": "pr-2", "user_id": "u4"}}`, http.StatusConflict},
+		"inactive": {`{"first": {"pull_request_id": "pr-1", "user_id": "u3"}, "second": {"pull_request_id": "pr-2", "user_id": "idle"}}`, http.StatusConflict},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/pullRequest/swapReviewers", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
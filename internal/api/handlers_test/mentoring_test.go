@@ -0,0 +1,109 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetSeniority(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/setSeniority", strings.NewReader(`{"user_id": "u1", "seniority": "junior"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"seniority":"junior"`)
+}
+
+func TestSetSeniorityInvalid(t *testing.T) {
+	cases := map[string]string{
+		"missing": `{"user_id": "u1"}`,
+		"unknown": `{"user_id": "u1", "seniority": "principal"}`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/users/setSeniority", strings.NewReader(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestPatchUserSeniorityV2(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/users/u1", strings.NewReader(`{"seniority": "senior"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"seniority":"senior"`)
+}
+
+func TestSetMentoring(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/setMentoring", strings.NewReader(`{"team_name": "backend", "mentoring": true}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mentoring":true`)
+}
+
+func TestSetMentoringRequiresFlag(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/setMentoring", strings.NewReader(`{"team_name": "backend"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestPatchTeamMentoringV2(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/teams/backend", strings.NewReader(`{"mentoring": true}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"mentoring":true`)
+}
+
+func TestCreatePRWithShadowReviewer(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "pull_request_name": "Fix", "author_id": "mentor"}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"assigned_reviewers":["u3"]`)
+	assert.Contains(t, w.Body.String(), `"shadow_reviewers":["j1"]`)
+	assert.Contains(t, w.Body.String(), `{"user_id":"j1","source":"team","role":"shadow"}`)
+}
+
+func TestGetReviewShowsRole(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/users/getReview?user_id=j1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pull_request_id":"pr-1","pull_request_name":"","author_id":"u1","status":"OPEN","role":"reviewer"`)
+	assert.Contains(t, w.Body.String(), `"pull_request_id":"pr-2","pull_request_name":"","author_id":"mentor","status":"OPEN","role":"shadow"`)
+}
@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
+)
+
+func TestRequestIDIsPropagated(t *testing.T) {
+	core, logs := observer.New(zap.InfoLevel)
+
+	r := newTestRouterWith(middleware.RequestID, middleware.Logging(zap.New(core)))
+	req := httptest.NewRequest("GET", "/stats", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-42")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "req-42", w.Header().Get(middleware.RequestIDHeader))
+
+	entries := logs.FilterField(zap.String("request_id", "req-42")).All()
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, int64(http.StatusOK), entries[0].ContextMap()["status"])
+	}
+}
+
+func TestRequestIDIsGenerated(t *testing.T) {
+	r := newTestRouterWith(middleware.RequestID)
+	req := httptest.NewRequest("GET", "/stats", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.NotEmpty(t, w.Header().Get(middleware.RequestIDHeader))
+}
+
+func TestTracingNamesSpanByRoute(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	r := newTestRouterWith(middleware.Tracing(tp))
+	req := httptest.NewRequest("GET", "/stats", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "GET /stats", spans[0].Name())
+	}
+}
+
+func TestTracingNamesSpanByRouteBehindAppMiddlewares(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	// Same order as app.Run: Logging and Authenticate replace the request
+	// with copies carrying their own context values.
+	r := newTestRouterWith(
+		middleware.RequestID,
+		middleware.Tracing(tp),
+		middleware.Logging(zap.NewNop()),
+		middleware.Authenticate(&mockAuthService{}, "/healthz", "/readyz"),
+	)
+	req := httptest.NewRequest("GET", "/v2/pull-requests/pr-1", nil)
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	spans := recorder.Ended()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "GET /v2/pull-requests/{id}", spans[0].Name())
+		assert.Contains(t, spans[0].Attributes(), attribute.String("http.route", "GET /v2/pull-requests/{id}"))
+	}
+}
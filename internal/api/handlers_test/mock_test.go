@@ -2,15 +2,25 @@ package handlers_test
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/CodebyTecs/pr-assign-service/internal/archive"
+	"github.com/CodebyTecs/pr-assign-service/internal/codeowners"
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
 	"github.com/CodebyTecs/pr-assign-service/internal/service"
+	"github.com/CodebyTecs/pr-assign-service/internal/teamimport"
 )
 
 type mockUserService struct{}
 type mockTeamService struct{}
 type mockPRService struct{}
 type mockStatsService struct{}
+type mockHealthService struct{}
+type mockAuthService struct{}
 
 func (m *mockUserService) UpdateActivity(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
 	return &domain.User{
@@ -21,6 +31,119 @@ func (m *mockUserService) UpdateActivity(ctx context.Context, userID string, isA
 	}, nil
 }
 
+func (m *mockUserService) Patch(ctx context.Context, input service.PatchUserInput) (*domain.User, error) {
+	user := &domain.User{ID: input.UserID, Username: "Test", TeamName: "Test"}
+	if input.IsActive != nil {
+		user.IsActive = *input.IsActive
+	}
+	if input.MaxOpenReviews != nil && *input.MaxOpenReviews > 0 {
+		user.MaxOpenReviews = input.MaxOpenReviews
+	}
+	if input.Seniority != nil {
+		user.Seniority = *input.Seniority
+	}
+	return user, nil
+}
+
+func (m *mockUserService) Get(ctx context.Context, userID string) (*domain.User, error) {
+	if userID != "u1" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.User{ID: userID, Username: "Alice", TeamName: "backend", IsActive: true}, nil
+}
+
+func (m *mockUserService) List(ctx context.Context, input service.ListUsersInput) (*domain.UserPage, error) {
+	if input.Cursor == "bad" {
+		return nil, domain.ErrInvalidCursor
+	}
+	user := domain.User{ID: "u1", Username: "Alice", TeamName: input.TeamName, IsActive: true}
+	if input.IsActive != nil {
+		user.IsActive = *input.IsActive
+	}
+	return &domain.UserPage{Users: []domain.User{user}, NextCursor: "next"}, nil
+}
+
+func (m *mockUserService) Offboard(ctx context.Context, input service.OffboardUserInput) (*domain.OffboardResult, error) {
+	switch input.UserID {
+	case "u1":
+	case "gone":
+		return nil, domain.ErrUserDeleted
+	default:
+		return nil, domain.ErrNotFound
+	}
+
+	now := time.Now()
+	user := &domain.User{ID: input.UserID, Username: "Alice", TeamName: "backend", DeletedAt: &now}
+	if input.Anonymize {
+		user.Username = "deleted-user-0000"
+	}
+	return &domain.OffboardResult{
+		User:        user,
+		Reassigned:  []domain.ReviewerReplacement{{PullRequestID: "pr-1", ReplacedBy: "u2"}},
+		RemovedFrom: []string{"pr-2"},
+	}, nil
+}
+
+func (m *mockUserService) AddUnavailability(ctx context.Context, input service.AddUnavailabilityInput) (*domain.Unavailability, error) {
+	switch input.UserID {
+	case "u1":
+	case "gone":
+		return nil, domain.ErrUserDeleted
+	default:
+		return nil, domain.ErrNotFound
+	}
+
+	return &domain.Unavailability{
+		ID:       1,
+		UserID:   input.UserID,
+		StartsAt: input.StartsAt,
+		EndsAt:   input.EndsAt,
+		Reason:   input.Reason,
+	}, nil
+}
+
+func (m *mockUserService) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	if userID != "u1" {
+		return nil, domain.ErrNotFound
+	}
+	return []domain.Unavailability{{ID: 1, UserID: userID, Reason: "vacation"}}, nil
+}
+
+func (m *mockUserService) RemoveUnavailability(ctx context.Context, userID string, id int64) error {
+	if userID != "u1" || id != 1 {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mockUserService) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	return []domain.Tag{{Name: "go"}, {Name: "sql"}}, nil
+}
+
+func (m *mockUserService) CreateTag(ctx context.Context, tag domain.Tag) (*domain.Tag, error) {
+	if tag.Name == "go" {
+		return nil, domain.ErrTagExists
+	}
+	return &tag, nil
+}
+
+func (m *mockUserService) GetTags(ctx context.Context, userID string) (*domain.UserTags, error) {
+	if userID != "u1" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.UserTags{UserID: userID, Tags: []string{"go"}}, nil
+}
+
+func (m *mockUserService) SetTags(ctx context.Context, userID string, tags []string) (*domain.UserTags, error) {
+	if userID != "u1" {
+		return nil, domain.ErrNotFound
+	}
+	if slices.Contains(tags, "cobol") {
+		return nil, fmt.Errorf("%w: cobol", domain.ErrUnknownTag)
+	}
+	return &domain.UserTags{UserID: userID, Tags: tags}, nil
+}
+
 func (m *mockUserService) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
 	return []domain.PullRequestShort{}, nil
 }
@@ -39,12 +162,145 @@ func (m *mockTeamService) Get(ctx context.Context, teamName string) (*domain.Tea
 	}, nil
 }
 
-func (m *mockPRService) Create(ctx context.Context, input service.CreatePRInput) (*domain.PullRequest, error) {
+func (m *mockTeamService) Update(ctx context.Context, input service.UpdateTeamInput) (*domain.Team, error) {
+	if input.Name == "missing" {
+		return nil, domain.ErrNotFound
+	}
+	if input.PartnerTeams != nil && slices.Contains(*input.PartnerTeams, "missing") {
+		return nil, domain.ErrNotFound
+	}
+	team := &domain.Team{Name: input.Name, CapacityPolicy: domain.CapacityPolicyReject, Members: []domain.TeamMember{}}
+	if input.CapacityPolicy != nil {
+		team.CapacityPolicy = *input.CapacityPolicy
+	}
+	if input.PartnerTeams != nil {
+		team.PartnerTeams = *input.PartnerTeams
+	}
+	if input.Mentoring != nil {
+		team.Mentoring = *input.Mentoring
+	}
+	return team, nil
+}
+
+func (m *mockTeamService) GetReviewRules(ctx context.Context, teamName string) (*domain.ReviewRules, error) {
+	if teamName != "backend" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.ReviewRules{
+		TeamName:      teamName,
+		AlwaysInclude: []domain.MandatoryReviewer{{UserID: "sec1", Paths: []string{"/internal/auth/"}}},
+		NeverPair:     []domain.NeverPair{},
+		RequireSenior: true,
+	}, nil
+}
+
+func (m *mockTeamService) SetReviewRules(ctx context.Context, rules domain.ReviewRules) (*domain.ReviewRules, []domain.ImportRowError, error) {
+	if rules.TeamName == "missing" {
+		return nil, nil, domain.ErrNotFound
+	}
+	for i, p := range rules.NeverPair {
+		if p.ReviewerID == "ghost" {
+			return nil, []domain.ImportRowError{{Row: i + 1, Field: "never_pair.reviewer_id", Message: `unknown user "ghost"`}}, domain.ErrInvalidReviewRules
+		}
+	}
+	return &rules, nil, nil
+}
+
+func (m *mockTeamService) GetCodeowners(ctx context.Context, teamName string) (*domain.Codeowners, error) {
+	if teamName != "backend" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.Codeowners{TeamName: teamName, Content: "* u1\n"}, nil
+}
+
+func (m *mockTeamService) SetCodeowners(ctx context.Context, teamName, content string) (*domain.Codeowners, []domain.ImportRowError, error) {
+	rs, rowErrs, err := codeowners.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rule := range rs.Rules() {
+		if slices.Contains(rule.Owners, "ghost") {
+			rowErrs = append(rowErrs, domain.ImportRowError{Row: rule.Line, Field: "owners", Message: `unknown user or team "ghost"`})
+		}
+	}
+	if len(rowErrs) > 0 {
+		return nil, rowErrs, domain.ErrInvalidCodeowners
+	}
+	return &domain.Codeowners{TeamName: teamName, Content: content}, nil, nil
+}
+
+func (m *mockTeamService) Import(ctx context.Context, input service.ImportTeamsInput) (*domain.TeamImportResult, error) {
+	teams, rowErrs, err := teamimport.Parse(input.Body, input.Format)
+	result := &domain.TeamImportResult{DryRun: input.DryRun, Errors: rowErrs}
+	if err != nil {
+		result.Errors = append(result.Errors, domain.ImportRowError{Message: err.Error()})
+	}
+	if len(result.Errors) > 0 {
+		return result, domain.ErrInvalidImport
+	}
+	result.TeamsCreated = len(teams)
+	for _, t := range teams {
+		result.UsersCreated += len(t.Members)
+	}
+	return result, nil
+}
+
+func (m *mockPRService) Create(ctx context.Context, input service.CreatePRInput) (*domain.PullRequest, []domain.AssignedReviewer, error) {
+	if input.Author == "busy" {
+		return nil, nil, domain.ErrAllAtCapacity
+	}
+	if slices.Contains(input.RequiredTags, "cobol") {
+		return nil, nil, fmt.Errorf("%w: cobol", domain.ErrUnknownTag)
+	}
+
+	assigned := []domain.AssignedReviewer{}
+	if len(input.Files) > 0 {
+		rule := &domain.CodeownersRule{Line: 1, Pattern: "*", Owners: []string{"u2"}}
+		assigned = append(assigned, domain.AssignedReviewer{UserID: "u2", Source: domain.ReviewerSourceCodeowners, Rule: rule})
+	}
+	assigned = append(assigned, domain.AssignedReviewer{UserID: "u3", Source: domain.ReviewerSourceTeam, CoveredTags: input.RequiredTags})
+	if input.Author == "small" {
+		assigned = append(assigned, domain.AssignedReviewer{UserID: "p1", Source: domain.ReviewerSourcePartner, TeamName: "platform"})
+	}
+
+	reviewers := make([]string, 0, len(assigned))
+	for _, a := range assigned {
+		reviewers = append(reviewers, a.UserID)
+	}
+
+	var shadows []string
+	if input.Author == "mentor" {
+		assigned = append(assigned, domain.AssignedReviewer{UserID: "j1", Source: domain.ReviewerSourceTeam, Role: domain.ReviewerRoleShadow})
+		shadows = []string{"j1"}
+	}
+
 	return &domain.PullRequest{
-		ID:       input.ID,
-		Name:     input.Name,
-		AuthorID: input.Author,
-		Status:   domain.PRStatusOpen,
+		ID:              input.ID,
+		Name:            input.Name,
+		AuthorID:        input.Author,
+		Status:          domain.PRStatusOpen,
+		Reviewers:       reviewers,
+		RequiredTags:    input.RequiredTags,
+		ShadowReviewers: shadows,
+	}, assigned, nil
+}
+
+func (m *mockPRService) Get(ctx context.Context, id string) (*domain.PullRequestDetails, error) {
+	if id != "pr-1" {
+		return nil, domain.ErrNotFound
+	}
+	return &domain.PullRequestDetails{
+		PullRequest: domain.PullRequest{
+			ID:        id,
+			Name:      "test-pr",
+			AuthorID:  "u1",
+			Status:    domain.PRStatusOpen,
+			Reviewers: []string{"u2"},
+		},
+		Author: &domain.User{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true},
+		ReviewerDetails: []domain.PullRequestReviewer{
+			{User: domain.User{ID: "u2", Username: "Bob", TeamName: "backend", IsActive: true}, Role: domain.ReviewerRoleReviewer},
+		},
 	}, nil
 }
 
@@ -55,23 +311,240 @@ func (m *mockPRService) Merge(ctx context.Context, id string) (*domain.PullReque
 	}, nil
 }
 
-func (m *mockPRService) Reassign(ctx context.Context, input service.ReassignReviewerInput) (*domain.PullRequest, string, error) {
+func (m *mockPRService) Reassign(ctx context.Context, input service.ReassignReviewerInput) (*domain.PullRequest, *domain.AssignedReviewer, error) {
+	if input.ReviewerID == "busy" {
+		return nil, nil, domain.ErrAllAtCapacity
+	}
+	assigned := &domain.AssignedReviewer{UserID: "id-new", Source: domain.ReviewerSourceTeam}
+	if input.ReviewerID == "lonely" {
+		assigned.Source = domain.ReviewerSourcePartner
+		assigned.TeamName = "platform"
+	}
+	return &domain.PullRequest{
+		ID:        input.PullRequestID,
+		Status:    domain.PRStatusOpen,
+		Reviewers: []string{assigned.UserID},
+	}, assigned, nil
+}
+
+func (m *mockPRService) Decline(ctx context.Context, input service.DeclineReviewInput) (*domain.PullRequest, *domain.AssignedReviewer, error) {
+	switch input.ReviewerID {
+	case "u9":
+		return nil, nil, domain.ErrNotAssigned
+	case "other":
+		return nil, nil, domain.ErrForbidden
+	}
 	return &domain.PullRequest{
 		ID:        input.PullRequestID,
 		Status:    domain.PRStatusOpen,
 		Reviewers: []string{"id-new"},
-	}, "id-new", nil
+	}, &domain.AssignedReviewer{UserID: "id-new", Source: domain.ReviewerSourceTeam}, nil
 }
 
-func (m *mockPRService) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
-	return []domain.PullRequestShort{}, nil
+func (m *mockPRService) ListByReviewer(ctx context.Context, reviewerID string, input service.ListPRsInput) (*domain.PRPage, error) {
+	if reviewerID == "j1" {
+		return &domain.PRPage{PullRequests: []domain.PullRequestShort{
+			{ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen, Role: domain.ReviewerRoleReviewer},
+			{ID: "pr-2", AuthorID: "mentor", Status: domain.PRStatusOpen, Role: domain.ReviewerRoleShadow},
+		}}, nil
+	}
+	return m.List(ctx, input)
+}
+
+func (m *mockPRService) List(ctx context.Context, input service.ListPRsInput) (*domain.PRPage, error) {
+	if input.Cursor == "bad" {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &domain.PRPage{
+		PullRequests: []domain.PullRequestShort{{ID: "pr-1", AuthorID: input.AuthorID, Status: domain.PRStatusOpen}},
+		NextCursor:   "next",
+	}, nil
+}
+
+func (m *mockPRService) Preview(ctx context.Context, input service.CreatePRInput) (*domain.AssignmentPreview, error) {
+	switch {
+	case input.Author == "missing":
+		return nil, domain.ErrNotFound
+	case slices.Contains(input.RequiredTags, "cobol"):
+		return nil, fmt.Errorf("%w: cobol", domain.ErrUnknownTag)
+	case input.Author == "busy":
+		return &domain.AssignmentPreview{
+			AuthorID:   input.Author,
+			Reviewers:  []domain.AssignedReviewer{},
+			Alternates: []string{},
+			Exclusions: []domain.Exclusion{{UserID: "u2", Reason: domain.ExclusionAtCapacity}},
+			Strategy:   domain.DecisionStrategyRandom,
+			Seed:       7,
+		}, nil
+	}
+	return &domain.AssignmentPreview{
+		AuthorID:   input.Author,
+		Reviewers:  []domain.AssignedReviewer{{UserID: "u3", Source: domain.ReviewerSourceTeam}},
+		Alternates: []string{"u4"},
+		Exclusions: []domain.Exclusion{{UserID: input.Author, Reason: domain.ExclusionAuthor}},
+		Strategy:   domain.DecisionStrategyRandom,
+		Seed:       7,
+	}, nil
+}
+
+func (m *mockPRService) ListDecisions(ctx context.Context, id string) ([]domain.AssignmentDecision, error) {
+	if id != "pr-1" {
+		return nil, domain.ErrNotFound
+	}
+	return []domain.AssignmentDecision{{
+		ID:            1,
+		PullRequestID: id,
+		Kind:          domain.DecisionKindCreate,
+		Strategy:      domain.DecisionStrategyRandom,
+		Seed:          42,
+		Candidates:    []string{"u2", "u3", "u4"},
+		Exclusions: []domain.Exclusion{
+			{UserID: "u1", Reason: domain.ExclusionAuthor},
+			{UserID: "u5", Reason: domain.ExclusionAtCapacity},
+		},
+		Selected: []string{"u3", "u2"},
+		Actor:    "u1",
+	}}, nil
+}
+
+func (m *mockPRService) AddReviewer(ctx context.Context, input service.ReviewerChangeInput) (*domain.PullRequest, error) {
+	if err := mockManualReviewer(input.ReviewerID); err != nil {
+		return nil, err
+	}
+	return &domain.PullRequest{
+		ID:        input.PullRequestID,
+		Status:    domain.PRStatusOpen,
+		Reviewers: []string{"u2", input.ReviewerID},
+	}, nil
+}
+
+func (m *mockPRService) RemoveReviewer(ctx context.Context, input service.ReviewerChangeInput) (*domain.PullRequest, error) {
+	switch input.ReviewerID {
+	case "u2":
+		return &domain.PullRequest{
+			ID:        input.PullRequestID,
+			Status:    domain.PRStatusOpen,
+			Reviewers: []string{},
+		}, nil
+	case "missing":
+		return nil, domain.ErrNotFound
+	}
+	return nil, domain.ErrNotAssigned
+}
+
+func (m *mockPRService) SwapReviewers(ctx context.Context, input service.SwapReviewersInput) (*domain.PullRequest, *domain.PullRequest, error) {
+	if err := mockManualReviewer(input.First.ReviewerID); err != nil {
+		return nil, nil, err
+	}
+	if err := mockManualReviewer(input.Second.ReviewerID); err != nil {
+		return nil, nil, err
+	}
+	first := &domain.PullRequest{ID: input.First.PullRequestID, Status: domain.PRStatusOpen, Reviewers: []string{input.Second.ReviewerID}}
+	second := &domain.PullRequest{ID: input.Second.PullRequestID, Status: domain.PRStatusOpen, Reviewers: []string{input.First.ReviewerID}}
+	return first, second, nil
+}
+
+func mockManualReviewer(id string) error {
+	switch id {
+	case "missing":
+		return domain.ErrNotFound
+	case "u1":
+		return domain.ErrReviewerIsAuthor
+	case "idle":
+		return domain.ErrReviewerInactive
+	case "u2":
+		return domain.ErrAlreadyAssigned
+	}
+	return nil
 }
 
 func (m *mockStatsService) Get(ctx context.Context) (*domain.Stats, error) {
 	return &domain.Stats{
-		TotalPR:        0,
-		OpenPR:         0,
-		MergedPR:       0,
-		ReviewsPerUser: []domain.UserReviewStat{},
+		TotalPR:  0,
+		OpenPR:   0,
+		MergedPR: 0,
+		ReviewsPerUser: []domain.UserReviewStat{
+			{UserID: "u2", ReviewsCount: 3, DeclinedCount: 1, DeclineRate: 0.25},
+		},
 	}, nil
 }
+
+func (m *mockHealthService) Liveness(ctx context.Context) *domain.HealthReport {
+	return &domain.HealthReport{
+		Status: domain.HealthStatusOK,
+		Checks: []domain.HealthCheck{{Name: "process", Status: domain.HealthStatusOK}},
+	}
+}
+
+func (m *mockHealthService) Readiness(ctx context.Context) *domain.HealthReport {
+	return &domain.HealthReport{
+		Status: domain.HealthStatusFail,
+		Checks: []domain.HealthCheck{{Name: "shutdown", Status: domain.HealthStatusFail, Error: "server is shutting down"}},
+	}
+}
+
+func (m *mockHealthService) RegisterWorker(w service.Worker) {}
+
+func (m *mockHealthService) BeginShutdown() {}
+
+const testToken = "prs_test"
+
+func (m *mockAuthService) Authenticate(ctx context.Context, rawToken string) (*domain.Principal, error) {
+	if rawToken != testToken {
+		return nil, domain.ErrUnauthorized
+	}
+	return &domain.Principal{Subject: "token:test", Role: domain.RoleAdmin}, nil
+}
+
+func (m *mockAuthService) IssueToken(ctx context.Context, input service.IssueTokenInput) (string, *domain.APIToken, error) {
+	return "prs_new", &domain.APIToken{
+		ID:        "t1",
+		Name:      input.Name,
+		Role:      input.Role,
+		TeamName:  input.TeamName,
+		CreatedAt: time.Now(),
+	}, nil
+}
+
+func (m *mockAuthService) RevokeToken(ctx context.Context, id string) error {
+	if id != "t1" {
+		return domain.ErrNotFound
+	}
+	return nil
+}
+
+func (m *mockAuthService) EnsureBootstrapToken(ctx context.Context, rawToken string) error {
+	return nil
+}
+
+type mockAdminService struct{}
+
+func (m *mockAdminService) Export(ctx context.Context, w io.Writer) error {
+	enc := archive.NewEncoder(w)
+	if err := enc.WriteHeader(time.Now()); err != nil {
+		return err
+	}
+	return enc.WriteTeam(&domain.Team{Name: "backend"})
+}
+
+func (m *mockAdminService) Import(ctx context.Context, input service.ImportArchiveInput) (*domain.ArchiveImportResult, error) {
+	result := &domain.ArchiveImportResult{Mode: input.Mode}
+
+	a, rowErrs, err := archive.Read(input.Body)
+	if err != nil {
+		result.Errors = []domain.ImportRowError{{Message: err.Error()}}
+		return result, domain.ErrInvalidImport
+	}
+	if len(rowErrs) > 0 {
+		result.Errors = rowErrs
+		return result, domain.ErrInvalidImport
+	}
+	if input.Mode == domain.RestoreModeRestore {
+		return nil, domain.ErrNotEmpty
+	}
+
+	result.Teams.Created = len(a.Teams)
+	result.Users.Created = len(a.Users)
+	result.PullRequests.Created = len(a.PullRequests)
+	return result, nil
+}
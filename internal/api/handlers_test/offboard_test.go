@@ -0,0 +1,55 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserOffboard(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/offboard", strings.NewReader(`{"user_id": "u1"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reassigned":[{"pull_request_id":"pr-1","replaced_by":"u2"}]`)
+	assert.Contains(t, w.Body.String(), `"removed_from":["pr-2"]`)
+	assert.Contains(t, w.Body.String(), `"deleted_at"`)
+}
+
+func TestUserOffboardV2Anonymize(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("DELETE", "/v2/users/u1?anonymize=true", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"username":"deleted-user-0000"`)
+}
+
+func TestUserOffboardTwice(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/offboard", strings.NewReader(`{"user_id": "gone"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"USER_DELETED"`)
+}
+
+func TestUserOffboardRequiresUserID(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/offboard", strings.NewReader(`{}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -0,0 +1,64 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPRList(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/pullRequest/list?author_id=u1&status=OPEN&sort=asc&limit=10", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"author_id":"u1"`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+}
+
+func TestPRListV2(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/pull-requests?team_name=backend", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestPRListInvalidQuery(t *testing.T) {
+	cases := []string{
+		"status=CLOSED",
+		"sort=newest",
+		"limit=0",
+		"limit=1000",
+		"created_after=yesterday",
+		"cursor=bad",
+	}
+
+	r := newTestRouter()
+	for _, q := range cases {
+		req := httptest.NewRequest("GET", "/pullRequest/list?"+q, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+}
+
+func TestUserGetReviewPaginated(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/users/getReview?user_id=u1&created_after=2025-01-01T00:00:00Z&limit=1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":"u1"`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+}
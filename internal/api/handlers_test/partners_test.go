@@ -0,0 +1,87 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetPartnerTeams(t *testing.T) {
+	r := newTestRouter()
+	body := `{"team_name": "search", "partner_teams": ["platform", "backend"]}`
+	req := httptest.NewRequest("POST", "/team/setPartners", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"partner_teams":["platform","backend"]`)
+}
+
+func TestPatchTeamPartnersV2(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/teams/search", strings.NewReader(`{"partner_teams": ["platform"]}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"partner_teams":["platform"]`)
+}
+
+func TestSetPartnerTeamsInvalid(t *testing.T) {
+	cases := map[string]string{
+		"self":      `{"team_name": "search", "partner_teams": ["search"]}`,
+		"duplicate": `{"team_name": "search", "partner_teams": ["platform", "platform"]}`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/team/setPartners", strings.NewReader(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestSetPartnerTeamsUnknownPartner(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/setPartners", strings.NewReader(`{"team_name": "search", "partner_teams": ["missing"]}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestCreatePRWithPartnerReviewer(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "pull_request_name": "Fix", "author_id": "small"}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `{"user_id":"p1","source":"partner","team_name":"platform"}`)
+}
+
+func TestReassignToPartnerReviewer(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "old_user_id": "lonely"}`
+	req := httptest.NewRequest("POST", "/pullRequest/reassign", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"replaced_by":"id-new"`)
+	assert.Contains(t, w.Body.String(), `"source":"partner"`)
+}
@@ -0,0 +1,58 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPRGet(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=pr-1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("ETag"))
+	assert.Contains(t, w.Body.String(), `"assigned_reviewers":["u2"]`)
+	assert.Contains(t, w.Body.String(), `"author":{"user_id":"u1","username":"Alice"`)
+	assert.Contains(t, w.Body.String(), `"reviewers":[{"user_id":"u2","username":"Bob"`)
+	assert.Contains(t, w.Body.String(), `"role":"reviewer"`)
+}
+
+func TestPRGetNotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/pull-requests/pr-404", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestPRGetIfNoneMatch(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/pull-requests/pr-1", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	etag := w.Header().Get("ETag")
+
+	req = httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=pr-1", nil)
+	req.Header.Set("If-None-Match", `"stale", `+etag)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Equal(t, etag, w.Header().Get("ETag"))
+	assert.Empty(t, w.Body.String())
+
+	req = httptest.NewRequest("GET", "/pullRequest/get?pull_request_id=pr-1", nil)
+	req.Header.Set("If-None-Match", `"stale"`)
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
@@ -0,0 +1,59 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPreviewPR(t *testing.T) {
+	r := newTestRouter()
+	body := `{"author_id": "u1", "changed_files": ["/internal/service/user.go"]}`
+	req := httptest.NewRequest("POST", "/pullRequest/preview", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reviewers":[{"user_id":"u3","source":"team"}]`)
+	assert.Contains(t, w.Body.String(), `"alternates":["u4"]`)
+	assert.Contains(t, w.Body.String(), `{"user_id":"u1","reason":"author"}`)
+}
+
+func TestPreviewPRAllAtCapacity(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/v2/pull-requests/preview", strings.NewReader(`{"author_id": "busy"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reviewers":[]`)
+	assert.Contains(t, w.Body.String(), `{"user_id":"u2","reason":"at_capacity"}`)
+}
+
+func TestPreviewPRErrors(t *testing.T) {
+	cases := map[string]struct {
+		body string
+		code int
+	}{
+		"no author":      {`{}`, http.StatusBadRequest},
+		"unknown author": {`{"author_id": "missing"}`, http.StatusNotFound},
+		"unknown tag":    {`{"author_id": "u1", "required_tags": ["cobol"]}`, http.StatusBadRequest},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/pullRequest/preview", strings.NewReader(tc.body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.code, w.Code)
+		})
+	}
+}
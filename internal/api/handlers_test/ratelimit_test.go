@@ -0,0 +1,98 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
+	"github.com/CodebyTecs/pr-assign-service/internal/ratelimit"
+)
+
+// newRateLimitedRouter mirrors the app chain: the limiter runs between
+// resolving and requiring the principal.
+func newRateLimitedRouter() http.Handler {
+	return newTestRouterWith(
+		middleware.ResolvePrincipal(&mockAuthService{}, "/healthz"),
+		middleware.RateLimit(middleware.RateLimitOptions{
+			Limiter: ratelimit.NewMemoryLimiter(time.Minute),
+			Default: ratelimit.Limit{Rate: 100, Burst: 100},
+			Groups: map[string]ratelimit.Limit{
+				"stats": {Rate: 0.5, Burst: 1},
+			},
+			Exempt: []string{"/healthz"},
+		}),
+		middleware.RequirePrincipal("/healthz"),
+	)
+}
+
+func statsRequest(token string) *http.Request {
+	req := httptest.NewRequest("GET", "/stats", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestRateLimitReturns429WithRetryAfter(t *testing.T) {
+	r := newRateLimitedRouter()
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, statsRequest(testToken))
+	assert.Equal(t, http.StatusOK, first.Code)
+
+	second := httptest.NewRecorder()
+	r.ServeHTTP(second, statsRequest(testToken))
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+	assert.Equal(t, "2", second.Header().Get("Retry-After"))
+	assert.Contains(t, second.Body.String(), `"code":"RATE_LIMITED"`)
+}
+
+func TestRateLimitIsPerClientAndGroup(t *testing.T) {
+	r := newRateLimitedRouter()
+
+	r.ServeHTTP(httptest.NewRecorder(), statsRequest(""))
+
+	otherClient := httptest.NewRecorder()
+	r.ServeHTTP(otherClient, statsRequest(testToken))
+	assert.Equal(t, http.StatusOK, otherClient.Code)
+
+	otherGroup := httptest.NewRequest("GET", "/team/get?team_name=backend", nil)
+	otherGroup.Header.Set("Authorization", "Bearer "+testToken)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, otherGroup)
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestRateLimitKeysInvalidTokensByIP(t *testing.T) {
+	r := newRateLimitedRouter()
+
+	first := httptest.NewRecorder()
+	r.ServeHTTP(first, statsRequest("random-1"))
+	assert.Equal(t, http.StatusUnauthorized, first.Code)
+
+	second := httptest.NewRecorder()
+	r.ServeHTTP(second, statsRequest("random-2"))
+	assert.Equal(t, http.StatusTooManyRequests, second.Code)
+
+	valid := httptest.NewRecorder()
+	r.ServeHTTP(valid, statsRequest(testToken))
+	assert.Equal(t, http.StatusOK, valid.Code)
+}
+
+func TestRateLimitSkipsExemptPaths(t *testing.T) {
+	r := newTestRouterWith(middleware.RateLimit(middleware.RateLimitOptions{
+		Limiter: ratelimit.NewMemoryLimiter(time.Minute),
+		Default: ratelimit.Limit{Rate: 0.1, Burst: 1},
+		Exempt:  []string{"/healthz"},
+	}))
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("GET", "/healthz", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+}
@@ -0,0 +1,67 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetReviewRules(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/team/reviewRules?team_name=backend", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"always_include":[{"user_id":"sec1","paths":["/internal/auth/"]}]`)
+	assert.Contains(t, w.Body.String(), `"require_senior":true`)
+}
+
+func TestGetReviewRulesV2NotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/teams/missing/review-rules", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestSetReviewRules(t *testing.T) {
+	r := newTestRouter()
+	body := `{"team_name": "backend", "never_pair": [{"author_id": "u1", "reviewer_id": "u2"}]}`
+	req := httptest.NewRequest("POST", "/team/setReviewRules", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"never_pair":[{"author_id":"u1","reviewer_id":"u2"}]`)
+}
+
+func TestPutReviewRulesV2Invalid(t *testing.T) {
+	r := newTestRouter()
+	body := `{"never_pair": [{"author_id": "u1", "reviewer_id": "ghost"}]}`
+	req := httptest.NewRequest("PUT", "/v2/teams/backend/review-rules", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), "REVIEW_RULES_INVALID")
+	assert.Contains(t, w.Body.String(), `"field":"never_pair.reviewer_id"`)
+}
+
+func TestSetReviewRulesMissingTeamName(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/setReviewRules", strings.NewReader(`{"require_senior": true}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
@@ -4,21 +4,34 @@ import (
 	"net/http"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/api/handlers"
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
 	"github.com/CodebyTecs/pr-assign-service/internal/app"
 )
 
 func newTestRouter() http.Handler {
+	return newTestRouterWith()
+}
+
+func newTestRouterWith(mws ...middleware.Middleware) http.Handler {
 	userSvc := &mockUserService{}
 	teamSvc := &mockTeamService{}
 	prSvc := &mockPRService{}
 	statsSvc := &mockStatsService{}
+	healthSvc := &mockHealthService{}
+	authSvc := &mockAuthService{}
+	adminSvc := &mockAdminService{}
 
 	userHandler := handlers.NewUserHandler(userSvc, prSvc)
 	teamHandler := handlers.NewTeamHandler(teamSvc)
 	prHandler := handlers.NewPRHandler(prSvc)
 	statsHandler := handlers.NewStatsHandler(statsSvc)
+	healthHandler := handlers.NewHealthHandler(healthSvc)
+	authHandler := handlers.NewAuthHandler(authSvc)
+	adminHandler := handlers.NewAdminHandler(adminSvc)
+
+	r := app.NewRouter(userHandler, teamHandler, prHandler, statsHandler, healthHandler, authHandler, adminHandler)
 
-	r := app.NewRouter(userHandler, teamHandler, prHandler, statsHandler)
+	r.Use(mws...)
 
 	return r.Handler()
 }
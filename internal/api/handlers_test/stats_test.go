@@ -16,4 +16,5 @@ func TestStatsGet(t *testing.T) {
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `{"user_id":"u2","reviews_count":3,"declined_count":1,"decline_rate":0.25}`)
 }
@@ -0,0 +1,111 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTags(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/tags/list", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"go"`)
+}
+
+func TestCreateTag(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/v2/tags", strings.NewReader(`{"name": "Rust", "description": "Rust services"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"name":"rust"`)
+}
+
+func TestCreateTagExists(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/tags/add", strings.NewReader(`{"name": "go"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "TAG_EXISTS")
+}
+
+func TestCreateTagInvalidName(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/tags/add", strings.NewReader(`{"name": "no spaces"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestGetUserTags(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/users/tags?user_id=u1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tags":["go"]`)
+}
+
+func TestSetUserTagsV2(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PUT", "/v2/users/u1/tags", strings.NewReader(`{"tags": ["go", "sql"]}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"tags":["go","sql"]`)
+}
+
+func TestSetUserTagsUnknownTag(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/setTags", strings.NewReader(`{"user_id": "u1", "tags": ["cobol"]}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "UNKNOWN_TAG")
+}
+
+func TestCreatePRWithRequiredTags(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "pull_request_name": "Add index", "author_id": "u1", "required_tags": ["sql"]}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"required_tags":["sql"]`)
+	assert.Contains(t, w.Body.String(), `"covered_tags":["sql"]`)
+}
+
+func TestCreatePRUnknownTag(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "pr-1", "pull_request_name": "Add index", "author_id": "u1", "required_tags": ["cobol"]}`
+	req := httptest.NewRequest("POST", "/pullRequest/create", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "UNKNOWN_TAG")
+}
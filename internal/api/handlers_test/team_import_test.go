@@ -0,0 +1,76 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const importYAML = `
+teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+        is_active: false
+  - team_name: frontend
+    members:
+      - user_id: u3
+        username: Carol
+`
+
+func TestTeamImportYAML(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/import", strings.NewReader(importYAML))
+	req.Header.Set("Content-Type", "application/yaml")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"teams_created":2`)
+	assert.Contains(t, w.Body.String(), `"users_created":3`)
+}
+
+func TestTeamImportCSVDryRun(t *testing.T) {
+	r := newTestRouter()
+	body := "team_name,user_id,username,is_active\nbackend,u1,Alice,true\nbackend,u2,Bob,false\n"
+	req := httptest.NewRequest("POST", "/v2/teams/import?format=csv&dry_run=true", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"dry_run":true`)
+	assert.Contains(t, w.Body.String(), `"teams_created":1`)
+}
+
+func TestTeamImportRowErrors(t *testing.T) {
+	r := newTestRouter()
+	body := "team_name,user_id,username,is_active\nbackend,u1,Alice,maybe\n"
+	req := httptest.NewRequest("POST", "/team/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "text/csv")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"IMPORT_INVALID"`)
+	assert.Contains(t, w.Body.String(), `{"row":2,"field":"is_active","message":"must be true or false"}`)
+}
+
+func TestTeamImportUnknownFormat(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/team/import", strings.NewReader("{}"))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
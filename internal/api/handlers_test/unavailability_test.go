@@ -0,0 +1,99 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddUnavailability(t *testing.T) {
+	r := newTestRouter()
+	body := `{"user_id": "u1", "starts_at": "2030-01-01T00:00:00Z", "ends_at": "2030-01-15T00:00:00Z", "reason": "vacation"}`
+	req := httptest.NewRequest("POST", "/users/unavailability/add", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"ends_at":"2030-01-15T00:00:00Z"`)
+	assert.Contains(t, w.Body.String(), `"reason":"vacation"`)
+}
+
+func TestAddUnavailabilityV2(t *testing.T) {
+	r := newTestRouter()
+	body := `{"starts_at": "2030-01-01T00:00:00Z", "ends_at": "2030-01-15T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/v2/users/u1/unavailability", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":"u1"`)
+}
+
+func TestAddUnavailabilityInvalidPeriod(t *testing.T) {
+	cases := map[string]string{
+		"missing ends_at": `{"user_id": "u1", "starts_at": "2030-01-01T00:00:00Z"}`,
+		"reversed":        `{"user_id": "u1", "starts_at": "2030-01-15T00:00:00Z", "ends_at": "2030-01-01T00:00:00Z"}`,
+		"in the past":     `{"user_id": "u1", "starts_at": "2000-01-01T00:00:00Z", "ends_at": "2000-01-15T00:00:00Z"}`,
+	}
+
+	for name, body := range cases {
+		t.Run(name, func(t *testing.T) {
+			r := newTestRouter()
+			req := httptest.NewRequest("POST", "/users/unavailability/add", strings.NewReader(body))
+			w := httptest.NewRecorder()
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+		})
+	}
+}
+
+func TestAddUnavailabilityDeletedUser(t *testing.T) {
+	r := newTestRouter()
+	body := `{"user_id": "gone", "starts_at": "2030-01-01T00:00:00Z", "ends_at": "2030-01-15T00:00:00Z"}`
+	req := httptest.NewRequest("POST", "/users/unavailability/add", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"USER_DELETED"`)
+}
+
+func TestListUnavailability(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/users/unavailability/list?user_id=u1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"reason":"vacation"`)
+}
+
+func TestRemoveUnavailability(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/users/unavailability/delete", strings.NewReader(`{"user_id": "u1", "unavailability_id": 1}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"removed":true`)
+}
+
+func TestRemoveUnavailabilityV2NotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("DELETE", "/v2/users/u1/unavailability/2", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
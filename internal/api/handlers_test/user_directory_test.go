@@ -0,0 +1,54 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserGet(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/users/get?user_id=u1", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"username":"Alice"`)
+}
+
+func TestUserGetNotFound(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/users/u404", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestUserList(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/users/list?team_name=backend&is_active=false&q=ali&limit=5", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"team_name":"backend","is_active":false`)
+	assert.Contains(t, w.Body.String(), `"next_cursor":"next"`)
+}
+
+func TestUserListInvalidQuery(t *testing.T) {
+	r := newTestRouter()
+	for _, q := range []string{"is_active=maybe", "limit=-1", "cursor=bad"} {
+		req := httptest.NewRequest("GET", "/v2/users?"+q, nil)
+		w := httptest.NewRecorder()
+
+		r.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code, q)
+	}
+}
@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestV2PRCreate(t *testing.T) {
+	r := newTestRouter()
+	body := `{"pull_request_id": "1", "pull_request_name": "test-pr", "author_id": "1"}`
+	req := httptest.NewRequest("POST", "/v2/pull-requests", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+}
+
+func TestV2PRMerge(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/v2/pull-requests/pr-1/merge", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"pull_request_id":"pr-1"`)
+}
+
+func TestV2PRReassign(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("POST", "/v2/pull-requests/pr-1/reassign", strings.NewReader(`{"old_user_id": "u1"}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestV2UserPatch(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/users/u1", strings.NewReader(`{"is_active": false}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":"u1"`)
+}
+
+func TestV2UserPatchRequiresIsActive(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("PATCH", "/v2/users/u1", strings.NewReader(`{}`))
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestV2UserReviews(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/users/u1/reviews", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"user_id":"u1"`)
+}
+
+func TestV2TeamGet(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/teams/backend", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+}
+
+func TestV2MethodNotAllowed(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/v2/pull-requests/pr-1/merge", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "POST", w.Header().Get("Allow"))
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"code":"METHOD_NOT_ALLOWED"`)
+}
+
+func TestV1MutationRejectsGet(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/pullRequest/merge", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+}
+
+func TestUnknownRouteIsJSON(t *testing.T) {
+	r := newTestRouter()
+	req := httptest.NewRequest("GET", "/nope", nil)
+	w := httptest.NewRecorder()
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.Contains(t, w.Body.String(), `"code":"NOT_FOUND"`)
+}
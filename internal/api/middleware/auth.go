@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+)
+
+// Authenticate resolves the bearer token of every request into a principal
+// stored in the context and rejects requests without a valid token. Requests
+// to publicPaths pass through untouched.
+func Authenticate(authService service.AuthService, publicPaths ...string) Middleware {
+	resolve := ResolvePrincipal(authService, publicPaths...)
+	require := RequirePrincipal(publicPaths...)
+
+	return func(next http.Handler) http.Handler {
+		return resolve(require(next))
+	}
+}
+
+// ResolvePrincipal stores the principal of a valid bearer token in the
+// context. Requests with a missing or invalid token pass through without one,
+// so that middlewares placed before RequirePrincipal can tell them apart.
+func ResolvePrincipal(authService service.AuthService, publicPaths ...string) Middleware {
+	public := pathSet(publicPaths)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := public[r.URL.Path]; ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			raw, ok := bearerToken(r)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := authService.Authenticate(r.Context(), raw)
+			if err != nil {
+				if errors.Is(err, domain.ErrUnauthorized) {
+					next.ServeHTTP(w, r)
+					return
+				}
+				logger.FromContext(r.Context()).Error("authentication failed", zap.Error(err))
+				writeError(w, http.StatusInternalServerError, "INTERNAL", "internal error")
+				return
+			}
+
+			ctx := service.ContextWithPrincipal(r.Context(), principal)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// RequirePrincipal rejects requests that ResolvePrincipal found no principal for.
+func RequirePrincipal(publicPaths ...string) Middleware {
+	public := pathSet(publicPaths)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := public[r.URL.Path]; ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if _, ok := service.PrincipalFromContext(r.Context()); !ok {
+				if _, ok := bearerToken(r); ok {
+					writeUnauthorized(w, "invalid or revoked token")
+					return
+				}
+				writeUnauthorized(w, "bearer token is required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// StaticPrincipal attaches the same principal to every request. It is used
+// when authentication is disabled for local runs.
+func StaticPrincipal(p *domain.Principal) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(service.ContextWithPrincipal(r.Context(), p)))
+		})
+	}
+}
+
+func pathSet(paths []string) map[string]struct{} {
+	set := make(map[string]struct{}, len(paths))
+	for _, p := range paths {
+		set[p] = struct{}{}
+	}
+	return set
+}
+
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+	return token, token != ""
+}
+
+func writeUnauthorized(w http.ResponseWriter, message string) {
+	w.Header().Set("WWW-Authenticate", `Bearer realm="pr-assign-service"`)
+	writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", message)
+}
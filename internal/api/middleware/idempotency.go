@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/service"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	maxIdempotencyKeyLength = 255
+	maxIdempotentBodySize   = 1 << 20
+)
+
+type IdempotencyStore interface {
+	Reserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (*domain.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error
+	Release(ctx context.Context, scope, key string) error
+}
+
+// Idempotency replays the stored response of a POST request retried with the
+// same Idempotency-Key and body within ttl. Reusing a key with a different
+// body is rejected with 422. Keys are scoped to the authenticated caller, so
+// it must run after authentication. Server errors are not stored, so such
+// requests can be retried. Requests to exempt paths, such as bulk imports
+// whose bodies exceed maxIdempotentBodySize, ignore the header.
+func Idempotency(store IdempotencyStore, ttl time.Duration, exemptPaths ...string) Middleware {
+	exempt := pathSet(exemptPaths)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if _, ok := exempt[r.URL.Path]; ok || r.Method != http.MethodPost || key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if len(key) > maxIdempotencyKeyLength {
+				writeError(w, http.StatusBadRequest, "BAD_REQUEST", "Idempotency-Key is too long")
+				return
+			}
+
+			ctx := r.Context()
+			log := logger.FromContext(ctx)
+
+			body, err := io.ReadAll(io.LimitReader(r.Body, maxIdempotentBodySize+1))
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "BAD_REQUEST", "can't read request body")
+				return
+			}
+			if len(body) > maxIdempotentBodySize {
+				writeError(w, http.StatusRequestEntityTooLarge, "PAYLOAD_TOO_LARGE", "request body is too large")
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			scope := idempotencyScope(r)
+			hash := requestHash(r, body)
+
+			existing, reserved, err := store.Reserve(ctx, scope, key, hash, ttl)
+			if err != nil {
+				log.Error("failed to reserve idempotency key", zap.Error(err))
+				writeError(w, http.StatusInternalServerError, "INTERNAL", "internal error")
+				return
+			}
+
+			if !reserved {
+				switch {
+				case existing.RequestHash != hash:
+					writeError(w, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED", "Idempotency-Key was already used with a different request")
+				case !existing.Completed():
+					writeError(w, http.StatusConflict, "IDEMPOTENCY_IN_PROGRESS", "a request with this Idempotency-Key is still in progress")
+				default:
+					if existing.ContentType != "" {
+						w.Header().Set("Content-Type", existing.ContentType)
+					}
+					w.Header().Set(IdempotencyReplayedHeader, "true")
+					w.WriteHeader(existing.StatusCode)
+					_, _ = w.Write(existing.ResponseBody)
+				}
+				return
+			}
+
+			// The client may be gone already; bookkeeping must still finish.
+			storeCtx := context.WithoutCancel(ctx)
+
+			// A panicking handler must not leave the key reserved until it expires.
+			defer func() {
+				if p := recover(); p != nil {
+					if err := store.Release(storeCtx, scope, key); err != nil {
+						log.Warn("failed to release idempotency key", zap.Error(err))
+					}
+					panic(p)
+				}
+			}()
+
+			rec := newBodyRecorder(w)
+			next.ServeHTTP(rec, r)
+
+			if rec.status >= http.StatusInternalServerError {
+				if err := store.Release(storeCtx, scope, key); err != nil {
+					log.Warn("failed to release idempotency key", zap.Error(err))
+				}
+				return
+			}
+
+			err = store.Complete(storeCtx, scope, key, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes())
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Warn("failed to store idempotent response", zap.Error(err))
+			}
+		})
+	}
+}
+
+func idempotencyScope(r *http.Request) string {
+	if p, ok := service.PrincipalFromContext(r.Context()); ok {
+		return p.Subject
+	}
+	return "anonymous"
+}
+
+func requestHash(r *http.Request, body []byte) string {
+	h := sha256.New()
+	_, _ = io.WriteString(h, r.Method+" "+r.URL.RequestURI()+"\n")
+	_, _ = h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// bodyRecorder writes the response through while keeping a copy of it.
+type bodyRecorder struct {
+	*statusRecorder
+	body bytes.Buffer
+}
+
+func newBodyRecorder(w http.ResponseWriter) *bodyRecorder {
+	return &bodyRecorder{statusRecorder: newStatusRecorder(w)}
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.statusRecorder.Write(b)
+}
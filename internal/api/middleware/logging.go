@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID takes the caller's X-Request-ID or generates one, echoes it back
+// and stores it in the request context.
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > maxRequestIDLength {
+			id = newRequestID()
+		}
+
+		w.Header().Set(RequestIDHeader, id)
+		ctx := context.WithValue(r.Context(), requestIDKey{}, id)
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Logging puts a request-scoped logger into the context and logs every
+// completed request. It must run after RequestID and Tracing.
+func Logging(base *zap.Logger) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			start := time.Now()
+
+			l := base.With(
+				zap.String("request_id", RequestIDFromContext(r.Context())),
+				zap.String("method", r.Method),
+				zap.String("path", r.URL.Path),
+			)
+			if sc := trace.SpanContextFromContext(r.Context()); sc.HasTraceID() {
+				l = l.With(zap.String("trace_id", sc.TraceID().String()))
+			}
+			ctx := logger.WithContext(r.Context(), l)
+
+			rec := newStatusRecorder(w)
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			l.Info("request completed",
+				zap.Int("status", rec.status),
+				zap.Int("bytes", rec.bytes),
+				zap.Duration("duration", time.Since(start)),
+				zap.String("remote_addr", r.RemoteAddr),
+			)
+		})
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
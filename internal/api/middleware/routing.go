@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+)
+
+// JSONRoutingErrors rewrites the plain text 404 and 405 responses produced by
+// http.ServeMux into the JSON error format used by the handlers.
+func JSONRoutingErrors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(&routingErrorWriter{ResponseWriter: w}, r)
+	})
+}
+
+type routingErrorWriter struct {
+	http.ResponseWriter
+	swallow bool
+}
+
+func (w *routingErrorWriter) WriteHeader(status int) {
+	plain := strings.HasPrefix(w.Header().Get("Content-Type"), "text/plain")
+	if !plain || (status != http.StatusNotFound && status != http.StatusMethodNotAllowed) {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+
+	w.swallow = true
+	w.Header().Del("X-Content-Type-Options")
+	if status == http.StatusNotFound {
+		writeError(w.ResponseWriter, status, "NOT_FOUND", "route not found")
+		return
+	}
+	writeError(w.ResponseWriter, status, "METHOD_NOT_ALLOWED", "method not allowed, allowed: "+w.Header().Get("Allow"))
+}
+
+func (w *routingErrorWriter) Write(b []byte) (int, error) {
+	if w.swallow {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *routingErrorWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/CodebyTecs/pr-assign-service/internal/api"
+
+type routeKey struct{}
+
+// routeHolder carries the matched route pattern from RecordRoute back out to
+// Tracing, since inner middlewares replace the request with copies.
+type routeHolder struct {
+	pattern string
+}
+
+// RecordRoute serves requests with mux and reports the pattern it matched to
+// Tracing. It must be the innermost handler.
+func RecordRoute(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if holder, ok := r.Context().Value(routeKey{}).(*routeHolder); ok {
+			_, holder.pattern = mux.Handler(r)
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// Tracing starts a server span per request, continuing the caller's trace
+// when traceparent headers are present. The span is renamed to the route
+// reported by RecordRoute once the request has been served.
+func Tracing(tp trace.TracerProvider) Middleware {
+	tracer := tp.Tracer(tracerName)
+	propagator := otel.GetTextMapPropagator()
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := propagator.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+
+			ctx, span := tracer.Start(ctx, r.Method+" "+r.URL.Path,
+				trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(
+					attribute.String("http.request.method", r.Method),
+					attribute.String("url.path", r.URL.Path),
+				),
+			)
+			defer span.End()
+
+			if id := RequestIDFromContext(ctx); id != "" {
+				span.SetAttributes(attribute.String("request.id", id))
+			}
+
+			holder := &routeHolder{}
+			ctx = context.WithValue(ctx, routeKey{}, holder)
+
+			rec := newStatusRecorder(w)
+			next.ServeHTTP(rec, r.WithContext(ctx))
+
+			if holder.pattern != "" {
+				route := holder.pattern
+				if !strings.Contains(route, " ") {
+					route = r.Method + " " + route
+				}
+				span.SetName(route)
+				span.SetAttributes(attribute.String("http.route", holder.pattern))
+			}
+			span.SetAttributes(attribute.Int("http.response.status_code", rec.status))
+			if rec.status >= http.StatusInternalServerError {
+				span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", rec.status))
+			}
+		})
+	}
+}
@@ -1,20 +1,39 @@
 package app
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/api/handlers"
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
 	"github.com/CodebyTecs/pr-assign-service/internal/config"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/oidc"
+	"github.com/CodebyTecs/pr-assign-service/internal/ratelimit"
 	"github.com/CodebyTecs/pr-assign-service/internal/repository"
 	"github.com/CodebyTecs/pr-assign-service/internal/service"
+	"github.com/CodebyTecs/pr-assign-service/internal/tracing"
+	"github.com/CodebyTecs/pr-assign-service/internal/worker"
 	"github.com/joho/godotenv"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
 )
 
 type Env struct {
 	Config   *config.Config
 	Postgres *sql.DB
+	Logger   *zap.Logger
+
+	shutdownTracing func(context.Context) error
 }
 
 func New() (*Env, error) {
@@ -25,6 +44,16 @@ func New() (*Env, error) {
 		return nil, err
 	}
 
+	log, err := logger.New(cfg.Environment, cfg.LogLevel)
+	if err != nil {
+		return nil, err
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		return nil, fmt.Errorf("can't setup tracing: %w", err)
+	}
+
 	postgre, err := provideDB(
 		fmt.Sprintf("postgres://%s:%s@%s:%s/%s?sslmode=disable",
 			cfg.Database.Username,
@@ -41,6 +70,9 @@ func New() (*Env, error) {
 	return &Env{
 		Config:   cfg,
 		Postgres: postgre,
+		Logger:   log,
+
+		shutdownTracing: shutdownTracing,
 	}, nil
 }
 
@@ -48,31 +80,178 @@ func (e *Env) Run() error {
 	userRepo := repository.NewUserRepository(e.Postgres)
 	teamRepo := repository.NewTeamRepository(e.Postgres)
 	prRepo := repository.NewPRRepository(e.Postgres)
+	healthRepo := repository.NewHealthRepository(e.Postgres)
+	tokenRepo := repository.NewTokenRepository(e.Postgres)
+	rateLimitRepo := repository.NewRateLimitRepository(e.Postgres)
+	idempotencyRepo := repository.NewIdempotencyRepository(e.Postgres)
+	unavailabilityRepo := repository.NewUnavailabilityRepository(e.Postgres)
+	codeownersRepo := repository.NewCodeownersRepository(e.Postgres)
+	reviewRulesRepo := repository.NewReviewRulesRepository(e.Postgres)
+	transactor := repository.NewTransactor(e.Postgres)
 
-	userSvc := service.NewUserService(userRepo)
-	teamSvc := service.NewTeamService(userRepo, teamRepo)
-	prSvc := service.NewPRService(prRepo, userRepo, teamRepo)
+	userSvc := service.NewUserService(userRepo, teamRepo, prRepo, unavailabilityRepo, reviewRulesRepo, tokenRepo, transactor)
+	teamSvc := service.NewTeamService(userRepo, teamRepo, codeownersRepo, reviewRulesRepo, transactor)
+	prSvc := service.NewPRService(prRepo, userRepo, teamRepo, unavailabilityRepo, codeownersRepo, reviewRulesRepo, transactor)
 	statsSvc := service.NewStatsService(prRepo)
+	healthSvc := service.NewHealthService(healthRepo)
+	adminSvc := service.NewAdminService(userRepo, teamRepo, prRepo, codeownersRepo, reviewRulesRepo, unavailabilityRepo, transactor)
+
+	var verifier service.IdentityVerifier
+	if e.Config.OIDC.Enabled {
+		v, err := oidc.NewVerifier(context.Background(), e.Config.OIDC)
+		if err != nil {
+			return fmt.Errorf("can't setup oidc: %w", err)
+		}
+		verifier = v
+	}
+	authSvc := service.NewAuthService(tokenRepo, teamRepo, userRepo, verifier)
 
 	userHandler := handlers.NewUserHandler(userSvc, prSvc)
 	teamHandler := handlers.NewTeamHandler(teamSvc)
 	prHandler := handlers.NewPRHandler(prSvc)
 	statsHandler := handlers.NewStatsHandler(statsSvc)
+	healthHandler := handlers.NewHealthHandler(healthSvc)
+	authHandler := handlers.NewAuthHandler(authSvc)
+	adminHandler := handlers.NewAdminHandler(adminSvc)
+
+	router := NewRouter(userHandler, teamHandler, prHandler, statsHandler, healthHandler, authHandler, adminHandler)
+	router.Use(
+		middleware.RequestID,
+		middleware.Tracing(otel.GetTracerProvider()),
+		middleware.Logging(e.Logger),
+	)
+
+	var workers []backgroundWorker
+
+	publicPaths := []string{"/healthz", "/readyz"}
+
+	// The rate limiter sits between resolving and requiring the principal so
+	// that it keys valid tokens by principal and everything else by IP.
+	if e.Config.Auth.Enabled {
+		if e.Config.Auth.BootstrapToken != "" {
+			if err := authSvc.EnsureBootstrapToken(context.Background(), e.Config.Auth.BootstrapToken); err != nil {
+				return fmt.Errorf("can't store bootstrap token: %w", err)
+			}
+		}
+		router.Use(middleware.ResolvePrincipal(authSvc, publicPaths...))
+	}
+
+	if e.Config.RateLimit.Enabled {
+		limiter, err := e.newRateLimiter(rateLimitRepo)
+		if err != nil {
+			return err
+		}
+		workers = append(workers, limiter)
+
+		groups := make(map[string]ratelimit.Limit, len(e.Config.RateLimit.GroupLimits))
+		for name, l := range e.Config.RateLimit.GroupLimits {
+			groups[name] = ratelimit.Limit{Rate: l.RPS, Burst: l.Burst}
+		}
+
+		router.Use(middleware.RateLimit(middleware.RateLimitOptions{
+			Limiter:    limiter,
+			Default:    ratelimit.Limit{Rate: e.Config.RateLimit.RPS, Burst: e.Config.RateLimit.Burst},
+			Groups:     groups,
+			TrustProxy: e.Config.RateLimit.TrustProxy,
+			Exempt:     publicPaths,
+		}))
+	}
+
+	if e.Config.Auth.Enabled {
+		router.Use(middleware.RequirePrincipal(publicPaths...))
+	} else {
+		e.Logger.Warn("authentication is disabled, every request runs as admin")
+		router.Use(middleware.StaticPrincipal(&domain.Principal{Subject: "anonymous", Role: domain.RoleAdmin}))
+	}
 
-	router := http.NewServeMux()
-	router.HandleFunc("/users/setIsActive", userHandler.SetIsActive)
-	router.HandleFunc("/users/getReview", userHandler.GetReview)
-	router.HandleFunc("/team/add", teamHandler.Add)
-	router.HandleFunc("/team/get", teamHandler.Get)
-	router.HandleFunc("/pullRequest/create", prHandler.Create)
-	router.HandleFunc("/pullRequest/reassign", prHandler.Reassign)
-	router.HandleFunc("/pullRequest/merge", prHandler.Merge)
-	router.HandleFunc("/stats", statsHandler.Get)
+	// Imports are too large to buffer and store as idempotent requests.
+	importPaths := []string{"/team/import", "/v2/teams/import", "/admin/import", "/v2/admin/import"}
+	router.Use(middleware.Idempotency(idempotencyRepo, e.Config.Idempotency.TTL, importPaths...))
+	workers = append(workers, worker.NewPeriodic("idempotency-cleanup", e.Config.Idempotency.CleanupInterval, e.Logger,
+		func(ctx context.Context) error {
+			_, err := idempotencyRepo.DeleteExpired(ctx)
+			return err
+		},
+	))
 
 	addr := fmt.Sprintf("%s:%s", e.Config.HTTPServer.Address, e.Config.HTTPServer.Port)
 
-	fmt.Println("Server listening on", addr)
-	return http.ListenAndServe(addr, router)
+	server := &http.Server{
+		Addr:         addr,
+		Handler:      router.Handler(),
+		ReadTimeout:  e.Config.HTTPServer.Timeout,
+		WriteTimeout: e.Config.HTTPServer.Timeout,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, w := range workers {
+		healthSvc.RegisterWorker(w)
+		wg.Add(1)
+		go func(w backgroundWorker) {
+			defer wg.Done()
+			w.Run(workersCtx)
+		}(w)
+	}
+	defer func() {
+		stopWorkers()
+		wg.Wait()
+	}()
+
+	errCh := make(chan error, 1)
+	go func() {
+		e.Logger.Info("server listening", zap.String("addr", addr))
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+	}
+
+	e.Logger.Info("shutting down")
+
+	// Fail readiness first so the load balancer stops routing traffic, then drain.
+	healthSvc.BeginShutdown()
+	time.Sleep(e.Config.HTTPServer.ShutdownDelay)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), e.Config.HTTPServer.ShutdownTimeout)
+	defer cancel()
+
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("can't shutdown server: %w", err)
+	}
+
+	return nil
+}
+
+// backgroundWorker runs until its context is cancelled and reports its state to readiness.
+type backgroundWorker interface {
+	service.Worker
+	Run(ctx context.Context)
+}
+
+type rateLimiterWorker interface {
+	ratelimit.Limiter
+	backgroundWorker
+}
+
+func (e *Env) newRateLimiter(repo repository.RateLimitRepository) (rateLimiterWorker, error) {
+	switch e.Config.RateLimit.Backend {
+	case "memory":
+		return ratelimit.NewMemoryLimiter(e.Config.RateLimit.IdleTTL), nil
+	case "postgres":
+		return ratelimit.NewPostgresLimiter(repo, e.Config.RateLimit.IdleTTL, e.Logger), nil
+	default:
+		return nil, fmt.Errorf("unknown rate limit backend %q", e.Config.RateLimit.Backend)
+	}
 }
 
 func provideDB(dsn string) (*sql.DB, error) {
@@ -94,5 +273,16 @@ func (e *Env) Close() error {
 		}
 	}
 
+	if e.shutdownTracing != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := e.shutdownTracing(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	if e.Logger != nil {
+		_ = e.Logger.Sync()
+	}
+
 	return firstErr
 }
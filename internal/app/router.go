@@ -4,30 +4,118 @@ import (
 	"net/http"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/api/handlers"
+	"github.com/CodebyTecs/pr-assign-service/internal/api/middleware"
 )
 
 type Router struct {
-	mux *http.ServeMux
+	mux         *http.ServeMux
+	middlewares []middleware.Middleware
 }
 
-func NewRouter(userHandler *handlers.UserHandler, teamHandler *handlers.TeamHandler, prHandler *handlers.PRHandler, statsHandler *handlers.StatsHandler) *Router {
+func NewRouter(userHandler *handlers.UserHandler, teamHandler *handlers.TeamHandler, prHandler *handlers.PRHandler, statsHandler *handlers.StatsHandler, healthHandler *handlers.HealthHandler, authHandler *handlers.AuthHandler, adminHandler *handlers.AdminHandler) *Router {
 	mux := http.NewServeMux()
 
-	mux.HandleFunc("/users/setIsActive", userHandler.SetIsActive)
-	mux.HandleFunc("/users/getReview", userHandler.GetReview)
+	// v1 is kept for existing clients.
+	mux.HandleFunc("POST /users/setIsActive", userHandler.SetIsActive)
+	mux.HandleFunc("GET /users/getReview", userHandler.GetReview)
+	mux.HandleFunc("GET /users/get", userHandler.Get)
+	mux.HandleFunc("GET /users/list", userHandler.List)
+	mux.HandleFunc("POST /users/setMaxOpenReviews", userHandler.SetMaxOpenReviews)
+	mux.HandleFunc("POST /users/setSeniority", userHandler.SetSeniority)
+	mux.HandleFunc("POST /users/offboard", userHandler.Offboard)
+	mux.HandleFunc("POST /users/unavailability/add", userHandler.AddUnavailability)
+	mux.HandleFunc("GET /users/unavailability/list", userHandler.ListUnavailability)
+	mux.HandleFunc("POST /users/unavailability/delete", userHandler.RemoveUnavailability)
+	mux.HandleFunc("GET /users/tags", userHandler.GetTags)
+	mux.HandleFunc("POST /users/setTags", userHandler.SetTags)
+	mux.HandleFunc("GET /tags/list", userHandler.ListTags)
+	mux.HandleFunc("POST /tags/add", userHandler.CreateTag)
 
-	mux.HandleFunc("/team/add", teamHandler.Add)
-	mux.HandleFunc("/team/get", teamHandler.Get)
+	mux.HandleFunc("POST /team/add", teamHandler.Add)
+	mux.HandleFunc("GET /team/get", teamHandler.Get)
+	mux.HandleFunc("POST /team/import", teamHandler.Import)
+	mux.HandleFunc("POST /team/setCapacityPolicy", teamHandler.SetCapacityPolicy)
+	mux.HandleFunc("POST /team/setPartners", teamHandler.SetPartnerTeams)
+	mux.HandleFunc("POST /team/setMentoring", teamHandler.SetMentoring)
+	mux.HandleFunc("GET /team/codeowners", teamHandler.GetCodeowners)
+	mux.HandleFunc("POST /team/setCodeowners", teamHandler.SetCodeowners)
+	mux.HandleFunc("GET /team/reviewRules", teamHandler.GetReviewRules)
+	mux.HandleFunc("POST /team/setReviewRules", teamHandler.SetReviewRules)
 
-	mux.HandleFunc("/pullRequest/create", prHandler.Create)
-	mux.HandleFunc("/pullRequest/merge", prHandler.Merge)
-	mux.HandleFunc("/pullRequest/reassign", prHandler.Reassign)
+	mux.HandleFunc("POST /pullRequest/create", prHandler.Create)
+	mux.HandleFunc("POST /pullRequest/preview", prHandler.Preview)
+	mux.HandleFunc("POST /pullRequest/merge", prHandler.Merge)
+	mux.HandleFunc("POST /pullRequest/reassign", prHandler.Reassign)
+	mux.HandleFunc("POST /pullRequest/decline", prHandler.Decline)
+	mux.HandleFunc("POST /pullRequest/addReviewer", prHandler.AddReviewer)
+	mux.HandleFunc("POST /pullRequest/removeReviewer", prHandler.RemoveReviewer)
+	mux.HandleFunc("POST /pullRequest/swapReviewers", prHandler.SwapReviewers)
+	mux.HandleFunc("GET /pullRequest/get", prHandler.Get)
+	mux.HandleFunc("GET /pullRequest/list", prHandler.List)
+	mux.HandleFunc("GET /pullRequest/decisions", prHandler.Decisions)
 
-	mux.HandleFunc("/stats", statsHandler.Get)
+	mux.HandleFunc("GET /stats", statsHandler.Get)
+
+	mux.HandleFunc("POST /auth/tokens/create", authHandler.CreateToken)
+	mux.HandleFunc("POST /auth/tokens/revoke", authHandler.RevokeToken)
+
+	mux.HandleFunc("GET /admin/export", adminHandler.Export)
+	mux.HandleFunc("POST /admin/import", adminHandler.Import)
+
+	// v2
+	mux.HandleFunc("GET /v2/users", userHandler.List)
+	mux.HandleFunc("GET /v2/users/{id}", userHandler.GetV2)
+	mux.HandleFunc("PATCH /v2/users/{id}", userHandler.PatchV2)
+	mux.HandleFunc("DELETE /v2/users/{id}", userHandler.DeleteV2)
+	mux.HandleFunc("GET /v2/users/{id}/reviews", userHandler.ReviewsV2)
+	mux.HandleFunc("GET /v2/users/{id}/unavailability", userHandler.ListUnavailabilityV2)
+	mux.HandleFunc("POST /v2/users/{id}/unavailability", userHandler.AddUnavailabilityV2)
+	mux.HandleFunc("DELETE /v2/users/{id}/unavailability/{unavailability_id}", userHandler.RemoveUnavailabilityV2)
+	mux.HandleFunc("GET /v2/users/{id}/tags", userHandler.GetTagsV2)
+	mux.HandleFunc("PUT /v2/users/{id}/tags", userHandler.SetTagsV2)
+	mux.HandleFunc("GET /v2/tags", userHandler.ListTags)
+	mux.HandleFunc("POST /v2/tags", userHandler.CreateTag)
+
+	mux.HandleFunc("POST /v2/teams", teamHandler.Add)
+	mux.HandleFunc("POST /v2/teams/import", teamHandler.Import)
+	mux.HandleFunc("GET /v2/teams/{name}", teamHandler.GetV2)
+	mux.HandleFunc("PATCH /v2/teams/{name}", teamHandler.PatchV2)
+	mux.HandleFunc("GET /v2/teams/{name}/codeowners", teamHandler.GetCodeownersV2)
+	mux.HandleFunc("PUT /v2/teams/{name}/codeowners", teamHandler.PutCodeownersV2)
+	mux.HandleFunc("GET /v2/teams/{name}/review-rules", teamHandler.GetReviewRulesV2)
+	mux.HandleFunc("PUT /v2/teams/{name}/review-rules", teamHandler.PutReviewRulesV2)
+
+	mux.HandleFunc("GET /v2/pull-requests", prHandler.List)
+	mux.HandleFunc("POST /v2/pull-requests", prHandler.Create)
+	mux.HandleFunc("POST /v2/pull-requests/preview", prHandler.Preview)
+	mux.HandleFunc("GET /v2/pull-requests/{id}", prHandler.GetV2)
+	mux.HandleFunc("POST /v2/pull-requests/{id}/merge", prHandler.MergeV2)
+	mux.HandleFunc("POST /v2/pull-requests/{id}/reassign", prHandler.ReassignV2)
+	mux.HandleFunc("POST /v2/pull-requests/{id}/decline", prHandler.DeclineV2)
+	mux.HandleFunc("POST /v2/pull-requests/{id}/reviewers", prHandler.AddReviewerV2)
+	mux.HandleFunc("DELETE /v2/pull-requests/{id}/reviewers/{user_id}", prHandler.RemoveReviewerV2)
+	mux.HandleFunc("POST /v2/pull-requests/swap-reviewers", prHandler.SwapReviewers)
+	mux.HandleFunc("GET /v2/pull-requests/{id}/decisions", prHandler.DecisionsV2)
+
+	mux.HandleFunc("GET /v2/stats", statsHandler.Get)
+
+	mux.HandleFunc("POST /v2/auth/tokens", authHandler.CreateToken)
+	mux.HandleFunc("DELETE /v2/auth/tokens/{id}", authHandler.RevokeTokenV2)
+
+	mux.HandleFunc("GET /v2/admin/export", adminHandler.Export)
+	mux.HandleFunc("POST /v2/admin/import", adminHandler.Import)
+
+	mux.HandleFunc("GET /healthz", healthHandler.Healthz)
+	mux.HandleFunc("GET /readyz", healthHandler.Readyz)
 
 	return &Router{mux: mux}
 }
 
+// Use appends middlewares; the first one added is the outermost.
+func (r *Router) Use(mws ...middleware.Middleware) {
+	r.middlewares = append(r.middlewares, mws...)
+}
+
 func (r *Router) Handler() http.Handler {
-	return r.mux
+	return middleware.Chain(middleware.JSONRoutingErrors(middleware.RecordRoute(r.mux)), r.middlewares...)
 }
@@ -0,0 +1,204 @@
+// Package archive reads and writes the JSON-lines data archive used by
+// /admin/export and /admin/import.
+//
+// The first line is a header carrying the format version; every following
+// line holds one record. Tags are written first, then teams, users with their
+// tags, team settings (CODEOWNERS files and review rules), unavailability
+// windows and pull requests, so records only refer to earlier lines.
+//
+// Version 1 archives hold only teams, users and pull requests; they are still
+// read.
+package archive
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+// Version is the archive format version written by Encoder.
+const Version = 2
+
+// maxLineSize bounds a single archive line.
+const maxLineSize = 1 << 20
+
+type RecordType string
+
+const (
+	RecordHeader      RecordType = "header"
+	RecordTeam        RecordType = "team"
+	RecordUser        RecordType = "user"
+	RecordPullRequest RecordType = "pull_request"
+
+	RecordTag            RecordType = "tag"
+	RecordUserTags       RecordType = "user_tags"
+	RecordCodeowners     RecordType = "codeowners"
+	RecordReviewRules    RecordType = "review_rules"
+	RecordUnavailability RecordType = "unavailability"
+)
+
+type Team struct {
+	Name           string                `json:"team_name"`
+	CapacityPolicy domain.CapacityPolicy `json:"capacity_policy,omitempty"`
+	PartnerTeams   []string              `json:"partner_teams,omitempty"`
+	Mentoring      bool                  `json:"mentoring,omitempty"`
+}
+
+type Record struct {
+	Type        RecordType          `json:"type"`
+	Version     int                 `json:"version,omitempty"`
+	ExportedAt  *time.Time          `json:"exported_at,omitempty"`
+	Team        *Team               `json:"team,omitempty"`
+	User        *domain.User        `json:"user,omitempty"`
+	PullRequest *domain.PullRequest `json:"pull_request,omitempty"`
+
+	Tag            *domain.Tag            `json:"tag,omitempty"`
+	UserTags       *domain.UserTags       `json:"user_tags,omitempty"`
+	Codeowners     *domain.Codeowners     `json:"codeowners,omitempty"`
+	ReviewRules    *domain.ReviewRules    `json:"review_rules,omitempty"`
+	Unavailability *domain.Unavailability `json:"unavailability,omitempty"`
+}
+
+// Item is a value read from the archive together with its line number.
+type Item[T any] struct {
+	Line  int
+	Value T
+}
+
+type Archive struct {
+	Version      int
+	ExportedAt   time.Time
+	Teams        []Item[Team]
+	Users        []Item[domain.User]
+	PullRequests []Item[domain.PullRequest]
+
+	Tags           []Item[domain.Tag]
+	UserTags       []Item[domain.UserTags]
+	Codeowners     []Item[domain.Codeowners]
+	ReviewRules    []Item[domain.ReviewRules]
+	Unavailability []Item[domain.Unavailability]
+}
+
+type Encoder struct {
+	enc *json.Encoder
+}
+
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{enc: json.NewEncoder(w)}
+}
+
+func (e *Encoder) WriteHeader(exportedAt time.Time) error {
+	return e.enc.Encode(Record{Type: RecordHeader, Version: Version, ExportedAt: &exportedAt})
+}
+
+func (e *Encoder) WriteTeam(t *domain.Team) error {
+	return e.enc.Encode(Record{Type: RecordTeam, Team: &Team{Name: t.Name, CapacityPolicy: t.CapacityPolicy, PartnerTeams: t.PartnerTeams, Mentoring: t.Mentoring}})
+}
+
+func (e *Encoder) WriteUser(u *domain.User) error {
+	return e.enc.Encode(Record{Type: RecordUser, User: u})
+}
+
+func (e *Encoder) WritePullRequest(pr *domain.PullRequest) error {
+	return e.enc.Encode(Record{Type: RecordPullRequest, PullRequest: pr})
+}
+
+func (e *Encoder) WriteTag(t *domain.Tag) error {
+	return e.enc.Encode(Record{Type: RecordTag, Tag: t})
+}
+
+func (e *Encoder) WriteUserTags(t *domain.UserTags) error {
+	return e.enc.Encode(Record{Type: RecordUserTags, UserTags: t})
+}
+
+func (e *Encoder) WriteCodeowners(c *domain.Codeowners) error {
+	return e.enc.Encode(Record{Type: RecordCodeowners, Codeowners: c})
+}
+
+func (e *Encoder) WriteReviewRules(r *domain.ReviewRules) error {
+	return e.enc.Encode(Record{Type: RecordReviewRules, ReviewRules: r})
+}
+
+func (e *Encoder) WriteUnavailability(u *domain.Unavailability) error {
+	return e.enc.Encode(Record{Type: RecordUnavailability, Unavailability: u})
+}
+
+var ErrUnsupportedVersion = errors.New("unsupported archive version")
+
+// Read parses a whole archive. Malformed records are reported as row errors;
+// an archive without a readable header is returned as an error.
+func Read(r io.Reader) (*Archive, []domain.ImportRowError, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var (
+		a       *Archive
+		rowErrs []domain.ImportRowError
+		line    int
+	)
+
+	for sc.Scan() {
+		line++
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			if a == nil {
+				return nil, nil, fmt.Errorf("line %d: can't parse header: %w", line, err)
+			}
+			rowErrs = append(rowErrs, domain.ImportRowError{Row: line, Message: "invalid json"})
+			continue
+		}
+
+		if a == nil {
+			if rec.Type != RecordHeader {
+				return nil, nil, fmt.Errorf("line %d: archive must start with a header", line)
+			}
+			if rec.Version < 1 || rec.Version > Version {
+				return nil, nil, fmt.Errorf("%w: %d", ErrUnsupportedVersion, rec.Version)
+			}
+			a = &Archive{Version: rec.Version}
+			if rec.ExportedAt != nil {
+				a.ExportedAt = *rec.ExportedAt
+			}
+			continue
+		}
+
+		switch {
+		case rec.Type == RecordTeam && rec.Team != nil:
+			a.Teams = append(a.Teams, Item[Team]{Line: line, Value: *rec.Team})
+		case rec.Type == RecordUser && rec.User != nil:
+			a.Users = append(a.Users, Item[domain.User]{Line: line, Value: *rec.User})
+		case rec.Type == RecordPullRequest && rec.PullRequest != nil:
+			a.PullRequests = append(a.PullRequests, Item[domain.PullRequest]{Line: line, Value: *rec.PullRequest})
+		case rec.Type == RecordTag && rec.Tag != nil:
+			a.Tags = append(a.Tags, Item[domain.Tag]{Line: line, Value: *rec.Tag})
+		case rec.Type == RecordUserTags && rec.UserTags != nil:
+			a.UserTags = append(a.UserTags, Item[domain.UserTags]{Line: line, Value: *rec.UserTags})
+		case rec.Type == RecordCodeowners && rec.Codeowners != nil:
+			a.Codeowners = append(a.Codeowners, Item[domain.Codeowners]{Line: line, Value: *rec.Codeowners})
+		case rec.Type == RecordReviewRules && rec.ReviewRules != nil:
+			a.ReviewRules = append(a.ReviewRules, Item[domain.ReviewRules]{Line: line, Value: *rec.ReviewRules})
+		case rec.Type == RecordUnavailability && rec.Unavailability != nil:
+			a.Unavailability = append(a.Unavailability, Item[domain.Unavailability]{Line: line, Value: *rec.Unavailability})
+		default:
+			rowErrs = append(rowErrs, domain.ImportRowError{Row: line, Field: "type", Message: fmt.Sprintf("unexpected record %q", rec.Type)})
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, nil, fmt.Errorf("can't read archive: %w", err)
+	}
+	if a == nil {
+		return nil, nil, errors.New("archive is empty")
+	}
+
+	return a, rowErrs, nil
+}
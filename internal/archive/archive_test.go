@@ -0,0 +1,87 @@
+package archive
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+func TestRoundTrip(t *testing.T) {
+	created := time.Date(2025, 11, 1, 9, 0, 0, 0, time.UTC)
+	merged := created.Add(time.Hour)
+
+	var buf bytes.Buffer
+	enc := NewEncoder(&buf)
+	require.NoError(t, enc.WriteHeader(created))
+	require.NoError(t, enc.WriteTag(&domain.Tag{Name: "go"}))
+	require.NoError(t, enc.WriteTeam(&domain.Team{Name: "backend"}))
+	require.NoError(t, enc.WriteUser(&domain.User{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}))
+	require.NoError(t, enc.WriteUserTags(&domain.UserTags{UserID: "u1", Tags: []string{"go"}}))
+	require.NoError(t, enc.WriteCodeowners(&domain.Codeowners{TeamName: "backend", Content: "* @u1\n", UpdatedAt: created}))
+	require.NoError(t, enc.WriteReviewRules(&domain.ReviewRules{TeamName: "backend", RequireSenior: true}))
+	require.NoError(t, enc.WriteUnavailability(&domain.Unavailability{UserID: "u1", StartsAt: created, EndsAt: merged}))
+	require.NoError(t, enc.WritePullRequest(&domain.PullRequest{
+		ID:        "pr-1",
+		Name:      "Add search",
+		AuthorID:  "u1",
+		Status:    domain.PRStatusMerged,
+		Reviewers: []string{"u2"},
+		CreatedAt: &created,
+		MergedAt:  &merged,
+		MergedBy:  "u1",
+	}))
+
+	a, rowErrs, err := Read(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrs)
+
+	assert.Equal(t, Version, a.Version)
+	require.Len(t, a.Teams, 1)
+	require.Len(t, a.Users, 1)
+	require.Len(t, a.PullRequests, 1)
+	assert.Equal(t, []string{"go"}, a.UserTags[0].Value.Tags)
+	assert.Equal(t, "* @u1\n", a.Codeowners[0].Value.Content)
+	assert.True(t, a.ReviewRules[0].Value.RequireSenior)
+	assert.True(t, merged.Equal(a.Unavailability[0].Value.EndsAt))
+	require.Len(t, a.Tags, 1)
+
+	pr := a.PullRequests[0]
+	assert.Equal(t, 9, pr.Line)
+	assert.Equal(t, []string{"u2"}, pr.Value.Reviewers)
+	assert.True(t, merged.Equal(*pr.Value.MergedAt))
+	assert.Equal(t, "u1", pr.Value.MergedBy)
+}
+
+func TestReadRequiresHeader(t *testing.T) {
+	_, _, err := Read(strings.NewReader(`{"type":"team","team":{"team_name":"backend"}}` + "\n"))
+	assert.Error(t, err)
+
+	_, _, err = Read(strings.NewReader(`{"type":"header","version":99}` + "\n"))
+	assert.ErrorIs(t, err, ErrUnsupportedVersion)
+}
+
+func TestReadAcceptsVersion1(t *testing.T) {
+	a, rowErrs, err := Read(strings.NewReader(`{"type":"header","version":1}` + "\n" + `{"type":"team","team":{"team_name":"backend"}}` + "\n"))
+	require.NoError(t, err)
+	assert.Empty(t, rowErrs)
+	assert.Equal(t, 1, a.Version)
+	assert.Len(t, a.Teams, 1)
+}
+
+func TestReadReportsBadRecords(t *testing.T) {
+	doc := `{"type":"header","version":1}
+not json
+{"type":"user"}
+`
+	_, rowErrs, err := Read(strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Len(t, rowErrs, 2)
+	assert.Equal(t, 2, rowErrs[0].Row)
+	assert.Equal(t, 3, rowErrs[1].Row)
+}
@@ -0,0 +1,178 @@
+// Package codeowners parses CODEOWNERS files.
+//
+// Every non-empty line that is not a comment holds a gitignore-style pattern
+// followed by one or more owners, which are user ids or team names:
+//
+//	# default owners
+//	*                 backend
+//	/internal/auth/   u1 u7
+//	*.sql             dba
+//
+// A leading @ on an owner is ignored. When several rules match a path the last
+// one wins, as in GitHub CODEOWNERS.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type rule struct {
+	domain.CodeownersRule
+	re *regexp.Regexp
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	rules []rule
+}
+
+// Parse reads rules from r. Lines that cannot be parsed are reported as row
+// errors; a file that cannot be read at all is returned as an error.
+func Parse(r io.Reader) (*Ruleset, []domain.ImportRowError, error) {
+	var (
+		rs      Ruleset
+		rowErrs []domain.ImportRowError
+		line    int
+	)
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line++
+
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if i := strings.Index(text, " #"); i != -1 {
+			text = strings.TrimSpace(text[:i])
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) < 2 {
+			rowErrs = append(rowErrs, domain.ImportRowError{Row: line, Field: "owners", Message: "pattern has no owners"})
+			continue
+		}
+
+		re, err := compile(fields[0])
+		if err != nil {
+			rowErrs = append(rowErrs, domain.ImportRowError{Row: line, Field: "pattern", Message: err.Error()})
+			continue
+		}
+
+		owners := make([]string, 0, len(fields)-1)
+		for _, o := range fields[1:] {
+			o = strings.TrimPrefix(o, "@")
+			if o == "" {
+				rowErrs = append(rowErrs, domain.ImportRowError{Row: line, Field: "owners", Message: "empty owner"})
+				continue
+			}
+			owners = append(owners, o)
+		}
+
+		rs.rules = append(rs.rules, rule{
+			CodeownersRule: domain.CodeownersRule{Line: line, Pattern: fields[0], Owners: owners},
+			re:             re,
+		})
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, nil, fmt.Errorf("can't read codeowners: %w", err)
+	}
+
+	return &rs, rowErrs, nil
+}
+
+// Match returns the last rule matching path, or nil.
+func (rs *Ruleset) Match(path string) *domain.CodeownersRule {
+	path = strings.TrimPrefix(path, "/")
+
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		if rs.rules[i].re.MatchString(path) {
+			r := rs.rules[i].CodeownersRule
+			return &r
+		}
+	}
+
+	return nil
+}
+
+// Rules returns the rules in file order.
+func (rs *Ruleset) Rules() []domain.CodeownersRule {
+	result := make([]domain.CodeownersRule, 0, len(rs.rules))
+	for _, r := range rs.rules {
+		result = append(result, r.CodeownersRule)
+	}
+	return result
+}
+
+// Pattern is a single path pattern in CODEOWNERS syntax.
+type Pattern struct {
+	re *regexp.Regexp
+}
+
+// CompilePattern parses pattern for use outside a CODEOWNERS file.
+func CompilePattern(pattern string) (*Pattern, error) {
+	re, err := compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{re: re}, nil
+}
+
+// Match reports whether path matches the pattern.
+func (p *Pattern) Match(path string) bool {
+	return p.re.MatchString(strings.TrimPrefix(path, "/"))
+}
+
+// compile turns a gitignore-style pattern into a regular expression matched
+// against slash-separated paths without a leading slash. A pattern matches a
+// file or, if it names a directory, everything below it. Patterns without an
+// inner slash match at any depth.
+func compile(pattern string) (*regexp.Regexp, error) {
+	p := pattern
+	dirOnly := strings.HasSuffix(p, "/")
+	p = strings.TrimSuffix(p, "/")
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+
+	if p == "" {
+		return nil, fmt.Errorf("invalid pattern %q", pattern)
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		switch c := p[i]; {
+		case strings.HasPrefix(p[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	if dirOnly {
+		b.WriteString("/.*$")
+	} else {
+		b.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(b.String())
+}
@@ -0,0 +1,75 @@
+package codeowners
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	doc := `# comment
+*                backend
+*.sql            dba
+/internal/auth/  u1 @u7
+docs/**/*.md     writers
+build            ops # trailing comment
+`
+	rs, rowErrs, err := Parse(strings.NewReader(doc))
+	require.NoError(t, err)
+	require.Empty(t, rowErrs)
+
+	cases := map[string]int{
+		"main.go":                     2,
+		"migrations/0001_init.up.sql": 3,
+		"internal/auth/token.go":      4,
+		"/internal/auth/oidc/jwt.go":  4,
+		"pkg/internal/auth/x.go":      2,
+		"docs/api/v2/readme.md":       5,
+		"docs/readme.md":              5,
+		"build/out.bin":               6,
+		"cmd/build":                   6,
+		"cmd/builder":                 2,
+	}
+
+	for path, line := range cases {
+		rule := rs.Match(path)
+		require.NotNil(t, rule, path)
+		assert.Equal(t, line, rule.Line, path)
+	}
+
+	assert.Equal(t, []string{"u1", "u7"}, rs.Match("internal/auth/x.go").Owners)
+	assert.Len(t, rs.Rules(), 5)
+}
+
+func TestMatchNoRule(t *testing.T) {
+	rs, _, err := Parse(strings.NewReader("/api/ backend\n"))
+	require.NoError(t, err)
+
+	assert.Nil(t, rs.Match("web/api/index.js"))
+	assert.NotNil(t, rs.Match("api/index.js"))
+}
+
+func TestParseReportsLines(t *testing.T) {
+	rs, rowErrs, err := Parse(strings.NewReader("*.go backend\n\n/docs/\n/ u1\n"))
+	require.NoError(t, err)
+
+	require.Len(t, rowErrs, 2)
+	assert.Equal(t, 3, rowErrs[0].Row)
+	assert.Equal(t, "owners", rowErrs[0].Field)
+	assert.Equal(t, 4, rowErrs[1].Row)
+	assert.Equal(t, "pattern", rowErrs[1].Field)
+	assert.NotNil(t, rs.Match("main.go"))
+}
+
+func TestCompilePattern(t *testing.T) {
+	p, err := CompilePattern("/internal/auth/")
+	require.NoError(t, err)
+
+	assert.True(t, p.Match("/internal/auth/token.go"))
+	assert.False(t, p.Match("internal/authz/token.go"))
+
+	_, err = CompilePattern("/")
+	assert.Error(t, err)
+}
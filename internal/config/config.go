@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ilyakaznacheev/cleanenv"
@@ -9,15 +11,75 @@ import (
 
 type Config struct {
 	Environment string `env:"ENVIRONMENT"`
+	LogLevel    string `env:"LOG_LEVEL"`
 	Database    DatabaseConfig
 	HTTPServer  HTTPServerConfig
+	Tracing     TracingConfig
+	Auth        AuthConfig
+	OIDC        OIDCConfig
+	RateLimit   RateLimitConfig
+	Idempotency IdempotencyConfig
 }
 
 type HTTPServerConfig struct {
 	Address string        `env:"HTTP_SERVER_ADDRESS"`
 	Port    string        `env:"HTTP_SERVER_PORT"`
 	Timeout time.Duration `env:"HTTP_SERVER_TIMEOUT"`
+
+	ShutdownDelay   time.Duration `env:"HTTP_SERVER_SHUTDOWN_DELAY"`
+	ShutdownTimeout time.Duration `env:"HTTP_SERVER_SHUTDOWN_TIMEOUT"`
+}
+
+type TracingConfig struct {
+	// Exporter is one of "none", "stdout" or "otlp".
+	Exporter     string  `env:"OTEL_TRACES_EXPORTER"`
+	OTLPEndpoint string  `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
+	OTLPInsecure bool    `env:"OTEL_EXPORTER_OTLP_INSECURE"`
+	ServiceName  string  `env:"OTEL_SERVICE_NAME"`
+	SampleRatio  float64 `env:"OTEL_TRACES_SAMPLE_RATIO" env-default:"1"`
+}
+
+type AuthConfig struct {
+	Enabled bool `env:"AUTH_ENABLED" env-default:"true"`
+	// BootstrapToken is stored as an admin token on startup when set.
+	BootstrapToken string `env:"AUTH_BOOTSTRAP_TOKEN"`
+}
+
+type OIDCConfig struct {
+	Enabled bool `env:"OIDC_ENABLED"`
+	// JWKSFile takes precedence over JWKSURL and is meant for tests and offline runs.
+	JWKSURL    string `env:"OIDC_JWKS_URL"`
+	JWKSFile   string `env:"OIDC_JWKS_FILE"`
+	Issuer     string `env:"OIDC_ISSUER"`
+	Audience   string `env:"OIDC_AUDIENCE"`
+	UserClaim  string `env:"OIDC_USER_CLAIM" env-default:"sub"`
+	RolesClaim string `env:"OIDC_ROLES_CLAIM" env-default:"roles"`
+}
+
+type RateLimitConfig struct {
+	Enabled bool `env:"RATE_LIMIT_ENABLED"`
+	// Backend is "memory" for per-replica limits or "postgres" for shared ones.
+	Backend    string        `env:"RATE_LIMIT_BACKEND" env-default:"memory"`
+	RPS        float64       `env:"RATE_LIMIT_RPS" env-default:"10"`
+	Burst      int           `env:"RATE_LIMIT_BURST" env-default:"20"`
+	IdleTTL    time.Duration `env:"RATE_LIMIT_IDLE_TTL" env-default:"10m"`
+	TrustProxy bool          `env:"RATE_LIMIT_TRUST_PROXY"`
+	// Groups overrides limits per route group, e.g. "pullRequest:2/5,users:20/40".
+	Groups map[string]string `env:"RATE_LIMIT_GROUPS"`
+
+	GroupLimits map[string]RateLimit `env:"-"`
+}
+
+type RateLimit struct {
+	RPS   float64
+	Burst int
+}
+
+type IdempotencyConfig struct {
+	TTL             time.Duration `env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	CleanupInterval time.Duration `env:"IDEMPOTENCY_CLEANUP_INTERVAL" env-default:"10m"`
 }
+
 type DatabaseConfig struct {
 	Username string `env:"DB_USER"`
 	DBName   string `env:"DB_NAME"`
@@ -36,6 +98,48 @@ func Load() (*Config, error) {
 	if cfg.HTTPServer.Timeout == 0 {
 		cfg.HTTPServer.Timeout = 15 * time.Second
 	}
+	if cfg.HTTPServer.ShutdownDelay == 0 {
+		cfg.HTTPServer.ShutdownDelay = 5 * time.Second
+	}
+	if cfg.HTTPServer.ShutdownTimeout == 0 {
+		cfg.HTTPServer.ShutdownTimeout = 10 * time.Second
+	}
+
+	groups, err := parseRateLimitGroups(cfg.RateLimit.Groups)
+	if err != nil {
+		return nil, err
+	}
+	cfg.RateLimit.GroupLimits = groups
+
+	if cfg.Tracing.Exporter == "" {
+		cfg.Tracing.Exporter = "none"
+	}
+	if cfg.Tracing.ServiceName == "" {
+		cfg.Tracing.ServiceName = "pr-assign-service"
+	}
 
 	return &cfg, nil
 }
+
+func parseRateLimitGroups(groups map[string]string) (map[string]RateLimit, error) {
+	result := make(map[string]RateLimit, len(groups))
+	for group, spec := range groups {
+		rps, burst, ok := strings.Cut(spec, "/")
+		if !ok {
+			return nil, fmt.Errorf("invalid rate limit for group %q: want rps/burst, got %q", group, spec)
+		}
+
+		r, err := strconv.ParseFloat(rps, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit rps for group %q: %w", group, err)
+		}
+		b, err := strconv.Atoi(burst)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit burst for group %q: %w", group, err)
+		}
+
+		result[group] = RateLimit{RPS: r, Burst: b}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,37 @@
+package domain
+
+// RestoreMode controls how an archive is imported: restore requires an empty
+// database, merge adds missing records and reports the ones that differ.
+type RestoreMode string
+
+const (
+	RestoreModeRestore RestoreMode = "restore"
+	RestoreModeMerge   RestoreMode = "merge"
+)
+
+type ImportCounts struct {
+	Created int `json:"created"`
+	Skipped int `json:"skipped"`
+}
+
+// ArchiveConflict is an archived record that differs from the stored one and was not imported.
+type ArchiveConflict struct {
+	Line    int    `json:"line"`
+	Type    string `json:"type"`
+	ID      string `json:"id"`
+	Message string `json:"message"`
+}
+
+type ArchiveImportResult struct {
+	Mode           RestoreMode       `json:"mode"`
+	Teams          ImportCounts      `json:"teams"`
+	Users          ImportCounts      `json:"users"`
+	PullRequests   ImportCounts      `json:"pull_requests"`
+	Tags           ImportCounts      `json:"tags"`
+	UserTags       ImportCounts      `json:"user_tags"`
+	Codeowners     ImportCounts      `json:"codeowners"`
+	ReviewRules    ImportCounts      `json:"review_rules"`
+	Unavailability ImportCounts      `json:"unavailability"`
+	Conflicts      []ArchiveConflict `json:"conflicts,omitempty"`
+	Errors         []ImportRowError  `json:"errors,omitempty"`
+}
@@ -0,0 +1,58 @@
+package domain
+
+import "time"
+
+type Role string
+
+const (
+	RoleAdmin    Role = "admin"
+	RoleTeamLead Role = "team_lead"
+	RoleBot      Role = "bot"
+	RoleReadOnly Role = "read_only"
+
+	// RoleMember is given to people signed in through OIDC without a privileged role.
+	RoleMember Role = "member"
+)
+
+// Valid reports whether r can be assigned to an API token.
+func (r Role) Valid() bool {
+	switch r {
+	case RoleAdmin, RoleTeamLead, RoleBot, RoleReadOnly:
+		return true
+	}
+	return false
+}
+
+type APIToken struct {
+	ID        string     `db:"token_id"   json:"token_id"`
+	Name      string     `db:"name"       json:"name"`
+	Role      Role       `db:"role"       json:"role"`
+	TeamName  string     `db:"team_name"  json:"team_name,omitempty"`
+	UserID    string     `db:"user_id"    json:"user_id,omitempty"`
+	Hash      string     `db:"token_hash" json:"-"`
+	CreatedAt time.Time  `db:"created_at" json:"created_at"`
+	RevokedAt *time.Time `db:"revoked_at" json:"revoked_at,omitempty"`
+}
+
+// Principal is the authenticated caller of a request. UserID is set only
+// when the caller is a person known to the service.
+type Principal struct {
+	Subject  string `json:"subject"`
+	UserID   string `json:"user_id,omitempty"`
+	Role     Role   `json:"role"`
+	TeamName string `json:"team_name,omitempty"`
+}
+
+// ActorID identifies the principal in audit records.
+func (p *Principal) ActorID() string {
+	if p.UserID != "" {
+		return p.UserID
+	}
+	return p.Subject
+}
+
+// ExternalIdentity is what an identity provider asserts about the caller.
+type ExternalIdentity struct {
+	UserID string
+	Roles  []string
+}
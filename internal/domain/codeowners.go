@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// Codeowners is the CODEOWNERS file uploaded by a team.
+type Codeowners struct {
+	TeamName  string    `db:"team_name"  json:"team_name"`
+	Content   string    `db:"content"    json:"content"`
+	UpdatedAt time.Time `db:"updated_at" json:"updated_at"`
+}
+
+// CodeownersRule is one line of a CODEOWNERS file.
+type CodeownersRule struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
@@ -0,0 +1,73 @@
+package domain
+
+import "time"
+
+// DecisionKind is the operation an assignment decision was made for.
+type DecisionKind string
+
+const (
+	DecisionKindCreate   DecisionKind = "create"
+	DecisionKindReassign DecisionKind = "reassign"
+	DecisionKindOffboard DecisionKind = "offboard"
+	DecisionKindDecline  DecisionKind = "decline"
+)
+
+// DecisionStrategy is how reviewers were chosen among the candidates.
+type DecisionStrategy string
+
+const (
+	// DecisionStrategyRandom picks candidates below their review limits at random.
+	DecisionStrategyRandom DecisionStrategy = "random"
+	// DecisionStrategyLeastLoaded picks the least loaded candidates because
+	// everyone reached their review limit.
+	DecisionStrategyLeastLoaded DecisionStrategy = "least_loaded"
+	// DecisionStrategyTagCoverage picks the candidates covering the most required tags.
+	DecisionStrategyTagCoverage DecisionStrategy = "tag_coverage"
+)
+
+// ExclusionReason explains why a user was not a candidate.
+type ExclusionReason string
+
+const (
+	ExclusionAuthor          ExclusionReason = "author"
+	ExclusionInactive        ExclusionReason = "inactive"
+	ExclusionOffboarded      ExclusionReason = "offboarded"
+	ExclusionUnavailable     ExclusionReason = "unavailable"
+	ExclusionAlreadyAssigned ExclusionReason = "already_assigned"
+	ExclusionAtCapacity      ExclusionReason = "at_capacity"
+	ExclusionNeverPair       ExclusionReason = "never_pair"
+)
+
+type Exclusion struct {
+	UserID string          `json:"user_id"`
+	Reason ExclusionReason `json:"reason"`
+}
+
+// AssignmentDecision records how the reviewers of a pull request were chosen.
+// Candidates are the users the reviewers were drawn from; selecting again
+// from the same data with Seed picks the same reviewers.
+type AssignmentDecision struct {
+	ID            int64            `json:"decision_id"`
+	PullRequestID string           `json:"pull_request_id"`
+	Kind          DecisionKind     `json:"kind"`
+	Strategy      DecisionStrategy `json:"strategy"`
+	Seed          int64            `json:"seed"`
+	Candidates    []string         `json:"candidates"`
+	Exclusions    []Exclusion      `json:"exclusions"`
+	Selected      []string         `json:"selected"`
+	Actor         string           `json:"actor"`
+	CreatedAt     time.Time        `json:"created_at"`
+}
+
+// AssignmentPreview is the outcome of a dry run of the assignment of a new
+// pull request. Alternates are the other candidates in the order they would
+// have been considered. Since the pick is random, creating the pull request
+// may choose differently among the candidates.
+type AssignmentPreview struct {
+	AuthorID   string             `json:"author_id"`
+	Reviewers  []AssignedReviewer `json:"reviewers"`
+	Alternates []string           `json:"alternates"`
+	Exclusions []Exclusion        `json:"exclusions"`
+	Strategy   DecisionStrategy   `json:"strategy"`
+	Seed       int64              `json:"seed"`
+}
@@ -9,4 +9,36 @@ var (
 	ErrNotAssigned = errors.New("reviewer not assigned to pull request")
 	ErrNoCandidate = errors.New("no active candidate available for review")
 	ErrNotFound    = errors.New("resource not found")
+	ErrUserDeleted = errors.New("user has been offboarded")
+
+	ErrReviewerIsAuthor = errors.New("author cannot review own pull request")
+	ErrReviewerInactive = errors.New("reviewer is not active")
+	ErrAlreadyAssigned  = errors.New("reviewer already assigned to pull request")
+
+	// ErrMandatoryReviewer means an always-include reviewer would be replaced
+	// or removed with no other mandatory reviewer to take the place.
+	ErrMandatoryReviewer = errors.New("mandatory reviewer cannot be replaced")
+	// ErrNeverPaired means the team rules forbid the reviewer for the author.
+	ErrNeverPaired = errors.New("reviewer must never review the author")
+	// ErrSeniorRequired means a change would leave a pull request of a team
+	// requiring a senior without one.
+	ErrSeniorRequired = errors.New("pull request needs a senior reviewer")
+	// ErrLastReviewer means a change would leave an open pull request without reviewers.
+	ErrLastReviewer = errors.New("cannot remove the last reviewer")
+
+	// ErrAllAtCapacity means candidates exist but each has reached max_open_reviews.
+	ErrAllAtCapacity = errors.New("all candidates are at review capacity")
+
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+	ErrInvalidImport = errors.New("import document is invalid")
+	ErrNotEmpty      = errors.New("database is not empty")
+
+	ErrInvalidCodeowners  = errors.New("codeowners file is invalid")
+	ErrInvalidReviewRules = errors.New("review rules are invalid")
+
+	ErrTagExists  = errors.New("tag already exists")
+	ErrUnknownTag = errors.New("unknown tag")
+
+	ErrUnauthorized = errors.New("authentication required")
+	ErrForbidden    = errors.New("operation not permitted")
 )
@@ -0,0 +1,20 @@
+package domain
+
+type HealthStatus string
+
+const (
+	HealthStatusOK   HealthStatus = "ok"
+	HealthStatusFail HealthStatus = "fail"
+)
+
+type HealthCheck struct {
+	Name      string       `json:"name"`
+	Status    HealthStatus `json:"status"`
+	LatencyMs float64      `json:"latency_ms"`
+	Error     string       `json:"error,omitempty"`
+}
+
+type HealthReport struct {
+	Status HealthStatus  `json:"status"`
+	Checks []HealthCheck `json:"checks"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// IdempotencyRecord is a stored response for an Idempotency-Key. StatusCode is
+// zero while the original request is still being processed.
+type IdempotencyRecord struct {
+	Scope        string
+	Key          string
+	RequestHash  string
+	StatusCode   int
+	ContentType  string
+	ResponseBody []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+func (r *IdempotencyRecord) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -17,11 +17,144 @@ type PullRequest struct {
 	Reviewers []string   `db:"assigned_reviewers" json:"assigned_reviewers"`
 	CreatedAt *time.Time `db:"created_at"        json:"createdAt,omitempty"`
 	MergedAt  *time.Time `db:"merged_at"         json:"mergedAt,omitempty"`
+	MergedBy  string     `db:"merged_by"         json:"mergedBy,omitempty"`
+	// RequiredTags are expertise tags that the reviewers should cover together.
+	RequiredTags []string `db:"required_tags" json:"required_tags,omitempty"`
+	// ShadowReviewers are non-blocking junior reviewers, kept apart from Reviewers.
+	ShadowReviewers []string `db:"shadow_reviewers" json:"shadow_reviewers,omitempty"`
+}
+
+// PullRequestDetails is a pull request together with its author and reviewers.
+// ReviewerDetails lists the reviewers first and then the shadow reviewers.
+type PullRequestDetails struct {
+	PullRequest
+	Author          *User                 `json:"author"`
+	ReviewerDetails []PullRequestReviewer `json:"reviewers"`
+}
+
+// PullRequestReviewer is a reviewer of a pull request with their role in it.
+type PullRequestReviewer struct {
+	User
+	Role ReviewerRole `json:"role"`
+}
+
+// ReviewerSource tells why a reviewer was picked.
+type ReviewerSource string
+
+const (
+	ReviewerSourceTeam       ReviewerSource = "team"
+	ReviewerSourceCodeowners ReviewerSource = "codeowners"
+	// ReviewerSourcePartner marks a cross-team reviewer taken from a partner team.
+	ReviewerSourcePartner ReviewerSource = "partner"
+	// ReviewerSourceMandatory marks an always-include reviewer of the team rules.
+	ReviewerSourceMandatory ReviewerSource = "mandatory"
+	// ReviewerSourceSenior marks a senior added to satisfy the team rules.
+	ReviewerSourceSenior ReviewerSource = "senior"
+)
+
+// ReviewerRole tells whether a review counts for the pull request.
+type ReviewerRole string
+
+const (
+	ReviewerRoleReviewer ReviewerRole = "reviewer"
+	// ReviewerRoleShadow is a junior reviewing to learn. Shadow reviews do not
+	// block merging and do not count towards review limits.
+	ReviewerRoleShadow ReviewerRole = "shadow"
+)
+
+// AssignedReviewer explains the automatic assignment of one reviewer. Rule is
+// the CODEOWNERS rule that made the reviewer an owner of a changed path;
+// TeamName is set for reviewers from a partner team.
+type AssignedReviewer struct {
+	UserID   string          `json:"user_id"`
+	Source   ReviewerSource  `json:"source"`
+	TeamName string          `json:"team_name,omitempty"`
+	Role     ReviewerRole    `json:"role,omitempty"`
+	Rule     *CodeownersRule `json:"rule,omitempty"`
+	// CoveredTags are the required tags of the pull request the reviewer was picked for.
+	CoveredTags []string `json:"covered_tags,omitempty"`
 }
 
 type PullRequestShort struct {
-	ID       string   `json:"pull_request_id"`
-	Name     string   `json:"pull_request_name"`
-	AuthorID string   `json:"author_id"`
-	Status   PRStatus `json:"status"`
+	ID        string     `json:"pull_request_id"`
+	Name      string     `json:"pull_request_name"`
+	AuthorID  string     `json:"author_id"`
+	Status    PRStatus   `json:"status"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	// Role is set when listing the reviews of a user.
+	Role ReviewerRole `json:"role,omitempty"`
+}
+
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// PRCursor is the keyset position of the last pull request on a page.
+type PRCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
+// PRListFilter selects a page of pull requests ordered by (created_at, pull_request_id).
+type PRListFilter struct {
+	ReviewerID   string
+	AuthorID     string
+	TeamName     string
+	Status       PRStatus
+	CreatedAfter *time.Time
+	Sort         SortOrder
+	After        *PRCursor
+	// Limit of 0 returns every matching pull request.
+	Limit int
+}
+
+type PRPage struct {
+	PullRequests []PullRequestShort `json:"pull_requests"`
+	NextCursor   string             `json:"next_cursor,omitempty"`
+}
+
+type PREventType string
+
+const (
+	PREventMerged     PREventType = "MERGED"
+	PREventReassigned PREventType = "REASSIGNED"
+	// PREventReviewerRemoved is recorded when a reviewer is removed by hand or
+	// when an offboarded reviewer has no replacement.
+	PREventReviewerRemoved PREventType = "REVIEWER_REMOVED"
+	// PREventReviewerAdded is recorded when a reviewer is added by hand.
+	PREventReviewerAdded PREventType = "REVIEWER_ADDED"
+	// PREventReviewerSwapped is recorded on both pull requests whose reviewers were swapped.
+	PREventReviewerSwapped PREventType = "REVIEWER_SWAPPED"
+	// PREventDeclined is recorded when a reviewer declines a review and is
+	// replaced; Reason holds the DeclineReason.
+	PREventDeclined PREventType = "DECLINED"
+)
+
+// PREvent is an audit record of a change made to a pull request.
+type PREvent struct {
+	ID            int64       `db:"event_id"        json:"event_id"`
+	PullRequestID string      `db:"pull_request_id" json:"pull_request_id"`
+	Type          PREventType `db:"event_type"      json:"event_type"`
+	Actor         string      `db:"actor"           json:"actor"`
+	OldReviewerID string      `db:"old_reviewer_id" json:"old_reviewer_id,omitempty"`
+	NewReviewerID string      `db:"new_reviewer_id" json:"new_reviewer_id,omitempty"`
+	CreatedAt     time.Time   `db:"created_at"      json:"created_at"`
+
+	Reason DeclineReason `db:"reason" json:"reason,omitempty"`
+}
+
+// DeclineReason is why a reviewer declined a review.
+type DeclineReason string
+
+const (
+	DeclineReasonBusy               DeclineReason = "busy"
+	DeclineReasonNoContext          DeclineReason = "no_context"
+	DeclineReasonConflictOfInterest DeclineReason = "conflict_of_interest"
+)
+
+func (r DeclineReason) Valid() bool {
+	return r == DeclineReasonBusy || r == DeclineReasonNoContext || r == DeclineReasonConflictOfInterest
 }
@@ -1,8 +1,12 @@
 package domain
 
+// UserReviewStat counts the reviews of a user. DeclineRate is DeclinedCount
+// divided by all reviews the user was given, declined ones included.
 type UserReviewStat struct {
-	UserID       string `json:"user_id"`
-	ReviewsCount int    `json:"reviews_count"`
+	UserID        string  `json:"user_id"`
+	ReviewsCount  int     `json:"reviews_count"`
+	DeclinedCount int     `json:"declined_count"`
+	DeclineRate   float64 `json:"decline_rate"`
 }
 type Stats struct {
 	TotalPR        int              `json:"total_pr"`
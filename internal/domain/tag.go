@@ -0,0 +1,23 @@
+package domain
+
+import "regexp"
+
+// Tag is an expertise area from the catalog, such as go or security.
+type Tag struct {
+	Name        string `db:"name"        json:"name"`
+	Description string `db:"description" json:"description,omitempty"`
+}
+
+var tagNameRe = regexp.MustCompile(`^[a-z0-9][a-z0-9._+#-]{0,31}$`)
+
+// ValidTagName reports whether name can be used as a tag: up to 32 lowercase
+// letters, digits and . _ + # - characters.
+func ValidTagName(name string) bool {
+	return tagNameRe.MatchString(name)
+}
+
+// UserTags lists the expertise tags of a user.
+type UserTags struct {
+	UserID string   `json:"user_id"`
+	Tags   []string `json:"tags"`
+}
@@ -6,7 +6,28 @@ type TeamMember struct {
 	IsActive bool   `json:"is_active"`
 }
 
+// CapacityPolicy decides what happens when every candidate of a team has
+// reached their max_open_reviews.
+type CapacityPolicy string
+
+const (
+	// CapacityPolicyReject fails the assignment with ErrAllAtCapacity.
+	CapacityPolicyReject CapacityPolicy = "reject"
+	// CapacityPolicyLeastLoaded ignores the limits and picks the candidates with the fewest open reviews.
+	CapacityPolicyLeastLoaded CapacityPolicy = "least_loaded"
+)
+
+func (p CapacityPolicy) Valid() bool {
+	return p == CapacityPolicyReject || p == CapacityPolicyLeastLoaded
+}
+
 type Team struct {
-	Name    string       `db:"team_name" json:"team_name"`
-	Members []TeamMember `json:"members"`
+	Name           string         `db:"team_name"       json:"team_name"`
+	CapacityPolicy CapacityPolicy `db:"capacity_policy" json:"capacity_policy,omitempty"`
+	// PartnerTeams are asked for reviewers, in order, when the team cannot
+	// supply enough of them itself.
+	PartnerTeams []string     `db:"partner_teams" json:"partner_teams,omitempty"`
+	Members      []TeamMember `json:"members"`
+	// Mentoring adds a junior shadow reviewer to every pull request of the team.
+	Mentoring bool `db:"mentoring" json:"mentoring,omitempty"`
 }
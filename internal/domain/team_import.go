@@ -0,0 +1,30 @@
+package domain
+
+// TeamImportMember is a member row of a bulk team import. Row is the line of
+// the source document it was read from.
+type TeamImportMember struct {
+	Row      int
+	UserID   string
+	Username string
+	IsActive bool
+}
+
+type TeamImportTeam struct {
+	Row     int
+	Name    string
+	Members []TeamImportMember
+}
+
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type TeamImportResult struct {
+	DryRun       bool             `json:"dry_run"`
+	TeamsCreated int              `json:"teams_created"`
+	UsersCreated int              `json:"users_created"`
+	UsersUpdated int              `json:"users_updated"`
+	Errors       []ImportRowError `json:"errors,omitempty"`
+}
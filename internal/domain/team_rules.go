@@ -0,0 +1,28 @@
+package domain
+
+// ReviewRules are the reviewer constraints a team applies to pull requests
+// authored by its members.
+type ReviewRules struct {
+	TeamName string `json:"team_name"`
+	// AlwaysInclude reviewers are assigned to every matching pull request.
+	AlwaysInclude []MandatoryReviewer `json:"always_include"`
+	// NeverPair lists team members that must never review each other.
+	NeverPair []NeverPair `json:"never_pair"`
+	// RequireSenior makes at least one reviewer a user of SenioritySenior.
+	RequireSenior bool `json:"require_senior"`
+}
+
+// MandatoryReviewer is always assigned to pull requests changing a file that
+// matches one of Paths, or to every pull request if Paths is empty. Paths use
+// CODEOWNERS syntax.
+type MandatoryReviewer struct {
+	UserID string   `json:"user_id"`
+	Paths  []string `json:"paths,omitempty"`
+}
+
+// NeverPair forbids AuthorID and ReviewerID from reviewing each other's pull
+// requests, whichever of them is the author.
+type NeverPair struct {
+	AuthorID   string `json:"author_id"`
+	ReviewerID string `json:"reviewer_id"`
+}
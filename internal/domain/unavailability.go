@@ -0,0 +1,14 @@
+package domain
+
+import "time"
+
+// Unavailability is an out-of-office window during which the user is not
+// picked as a reviewer. Their is_active flag is left untouched.
+type Unavailability struct {
+	ID        int64     `db:"unavailability_id" json:"id"`
+	UserID    string    `db:"user_id"           json:"user_id"`
+	StartsAt  time.Time `db:"starts_at"         json:"starts_at"`
+	EndsAt    time.Time `db:"ends_at"           json:"ends_at"`
+	Reason    string    `db:"reason"            json:"reason,omitempty"`
+	CreatedAt time.Time `db:"created_at"        json:"created_at"`
+}
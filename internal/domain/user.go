@@ -1,8 +1,67 @@
 package domain
 
+import "time"
+
 type User struct {
-	ID       string `db:"user_id"   json:"user_id"`
-	Username string `db:"username"  json:"username"`
-	TeamName string `db:"team_name" json:"team_name"`
-	IsActive bool   `db:"is_active" json:"is_active"`
+	ID        string     `db:"user_id"    json:"user_id"`
+	Username  string     `db:"username"   json:"username"`
+	TeamName  string     `db:"team_name"  json:"team_name"`
+	IsActive  bool       `db:"is_active"  json:"is_active"`
+	DeletedAt *time.Time `db:"deleted_at" json:"deleted_at,omitempty"`
+	// MaxOpenReviews caps the open pull requests the user reviews at once; nil means no limit.
+	MaxOpenReviews *int      `db:"max_open_reviews" json:"max_open_reviews,omitempty"`
+	Seniority      Seniority `db:"seniority"        json:"seniority,omitempty"`
+}
+
+// Seniority is the experience level of a user.
+type Seniority string
+
+const (
+	SeniorityJunior Seniority = "junior"
+	SeniorityMiddle Seniority = "middle"
+	SenioritySenior Seniority = "senior"
+)
+
+func (s Seniority) Valid() bool {
+	return s == SeniorityJunior || s == SeniorityMiddle || s == SenioritySenior
+}
+
+// Deleted reports whether the user has been offboarded.
+func (u *User) Deleted() bool {
+	return u.DeletedAt != nil
+}
+
+// UserCursor is the keyset position of the last user on a page.
+type UserCursor struct {
+	Username string
+	ID       string
+}
+
+// UserListFilter selects a page of users ordered by (username, user_id).
+// Query matches a substring of the username, case-insensitively.
+type UserListFilter struct {
+	TeamName string
+	IsActive *bool
+	Query    string
+	After    *UserCursor
+	Limit    int
+}
+
+type UserPage struct {
+	Users      []User `json:"users"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type ReviewerReplacement struct {
+	PullRequestID string `json:"pull_request_id"`
+	ReplacedBy    string `json:"replaced_by"`
+}
+
+// OffboardResult describes what happened to the open reviews of an offboarded user.
+type OffboardResult struct {
+	User        *User                 `json:"user"`
+	Reassigned  []ReviewerReplacement `json:"reassigned"`
+	RemovedFrom []string              `json:"removed_from"`
+	// RevokedTokens is the number of API tokens of the user that were revoked.
+	RevokedTokens int `json:"revoked_tokens"`
 }
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type ctxKey struct{}
+
+// New builds a JSON logger for deployed environments and a console logger for local runs.
+func New(environment, level string) (*zap.Logger, error) {
+	var cfg zap.Config
+	switch environment {
+	case "", "local", "dev":
+		cfg = zap.NewDevelopmentConfig()
+	default:
+		cfg = zap.NewProductionConfig()
+	}
+
+	if level != "" {
+		lvl, err := zapcore.ParseLevel(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+		}
+		cfg.Level = zap.NewAtomicLevelAt(lvl)
+	}
+
+	return cfg.Build()
+}
+
+func WithContext(ctx context.Context, l *zap.Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext returns the request-scoped logger, or a no-op logger when none is set.
+func FromContext(ctx context.Context) *zap.Logger {
+	if l, ok := ctx.Value(ctxKey{}).(*zap.Logger); ok && l != nil {
+		return l
+	}
+	return zap.NewNop()
+}
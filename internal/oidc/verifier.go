@@ -0,0 +1,100 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/MicahParks/keyfunc/v3"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/config"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+var ErrInvalidToken = errors.New("invalid jwt")
+
+// Verifier validates JWTs issued by the OIDC provider and extracts the caller identity.
+type Verifier struct {
+	keyfunc    jwt.Keyfunc
+	parser     *jwt.Parser
+	userClaim  string
+	rolesClaim string
+}
+
+// NewVerifier loads the signing keys from cfg.JWKSFile when set, otherwise
+// from cfg.JWKSURL, which is refreshed in the background until ctx is done.
+func NewVerifier(ctx context.Context, cfg config.OIDCConfig) (*Verifier, error) {
+	var (
+		kf  keyfunc.Keyfunc
+		err error
+	)
+
+	switch {
+	case cfg.JWKSFile != "":
+		raw, readErr := os.ReadFile(cfg.JWKSFile)
+		if readErr != nil {
+			return nil, fmt.Errorf("can't read jwks file: %w", readErr)
+		}
+		kf, err = keyfunc.NewJWKSetJSON(raw)
+	case cfg.JWKSURL != "":
+		kf, err = keyfunc.NewDefaultCtx(ctx, []string{cfg.JWKSURL})
+	default:
+		return nil, errors.New("either jwks file or jwks url must be set")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("can't load jwks: %w", err)
+	}
+
+	opts := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+		jwt.WithExpirationRequired(),
+	}
+	if cfg.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.Issuer))
+	}
+	if cfg.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.Audience))
+	}
+
+	return &Verifier{
+		keyfunc:    kf.Keyfunc,
+		parser:     jwt.NewParser(opts...),
+		userClaim:  cfg.UserClaim,
+		rolesClaim: cfg.RolesClaim,
+	}, nil
+}
+
+func (v *Verifier) Verify(raw string) (*domain.ExternalIdentity, error) {
+	claims := jwt.MapClaims{}
+	if _, err := v.parser.ParseWithClaims(raw, claims, v.keyfunc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	userID, _ := claims[v.userClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("%w: claim %q is missing", ErrInvalidToken, v.userClaim)
+	}
+
+	return &domain.ExternalIdentity{
+		UserID: userID,
+		Roles:  stringsClaim(claims[v.rolesClaim]),
+	}, nil
+}
+
+func stringsClaim(v any) []string {
+	switch t := v.(type) {
+	case string:
+		return []string{t}
+	case []any:
+		out := make([]string, 0, len(t))
+		for _, item := range t {
+			if s, ok := item.(string); ok {
+				out = append(out, s)
+			}
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,131 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/config"
+	"github.com/CodebyTecs/pr-assign-service/internal/oidc"
+)
+
+const testKID = "test-key"
+
+func newTestVerifier(t *testing.T) (*oidc.Verifier, *rsa.PrivateKey) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := map[string]any{
+		"keys": []map[string]any{{
+			"kty": "RSA",
+			"kid": testKID,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}},
+	}
+	raw, err := json.Marshal(jwks)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, raw, 0o600))
+
+	v, err := oidc.NewVerifier(context.Background(), config.OIDCConfig{
+		JWKSFile:   path,
+		Issuer:     "https://portal.example",
+		Audience:   "pr-assign-service",
+		UserClaim:  "sub",
+		RolesClaim: "roles",
+	})
+	require.NoError(t, err)
+
+	return v, key
+}
+
+func signToken(t *testing.T, key *rsa.PrivateKey, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testKID
+
+	raw, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return raw
+}
+
+func TestVerifyValidToken(t *testing.T) {
+	v, key := newTestVerifier(t)
+
+	raw := signToken(t, key, jwt.MapClaims{
+		"sub":   "u1",
+		"iss":   "https://portal.example",
+		"aud":   "pr-assign-service",
+		"exp":   time.Now().Add(time.Hour).Unix(),
+		"roles": []string{"admin"},
+	})
+
+	identity, err := v.Verify(raw)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", identity.UserID)
+	assert.Equal(t, []string{"admin"}, identity.Roles)
+}
+
+func TestVerifyRejectsExpiredToken(t *testing.T) {
+	v, key := newTestVerifier(t)
+
+	raw := signToken(t, key, jwt.MapClaims{
+		"sub": "u1",
+		"iss": "https://portal.example",
+		"aud": "pr-assign-service",
+		"exp": time.Now().Add(-time.Hour).Unix(),
+	})
+
+	_, err := v.Verify(raw)
+	assert.ErrorIs(t, err, oidc.ErrInvalidToken)
+}
+
+func TestVerifyRejectsWrongAudience(t *testing.T) {
+	v, key := newTestVerifier(t)
+
+	raw := signToken(t, key, jwt.MapClaims{
+		"sub": "u1",
+		"iss": "https://portal.example",
+		"aud": "someone-else",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	_, err := v.Verify(raw)
+	assert.ErrorIs(t, err, oidc.ErrInvalidToken)
+}
+
+func TestVerifyRejectsForeignKey(t *testing.T) {
+	v, _ := newTestVerifier(t)
+
+	other, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	raw := signToken(t, other, jwt.MapClaims{
+		"sub": "u1",
+		"iss": "https://portal.example",
+		"aud": "pr-assign-service",
+		"exp": time.Now().Add(time.Hour).Unix(),
+	})
+
+	_, err = v.Verify(raw)
+	assert.ErrorIs(t, err, oidc.ErrInvalidToken)
+}
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+// MemoryLimiter keeps buckets in process memory, so limits apply per replica.
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	idleTTL time.Duration
+	now     func() time.Time
+
+	running atomic.Bool
+}
+
+func NewMemoryLimiter(idleTTL time.Duration) *MemoryLimiter {
+	return &MemoryLimiter{
+		buckets: make(map[string]*bucket),
+		idleTTL: idleTTL,
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string, limit Limit) (Decision, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), updated: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.updated).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.Rate)
+	b.updated = now
+
+	allowed := b.tokens >= 1
+	if allowed {
+		b.tokens--
+	}
+
+	return decide(allowed, b.tokens, limit), nil
+}
+
+// Run evicts idle buckets until ctx is done.
+func (l *MemoryLimiter) Run(ctx context.Context) {
+	l.running.Store(true)
+	defer l.running.Store(false)
+
+	ticker := time.NewTicker(l.idleTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.evictIdle()
+		}
+	}
+}
+
+func (l *MemoryLimiter) evictIdle() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := l.now().Add(-l.idleTTL)
+	for key, b := range l.buckets {
+		if b.updated.Before(cutoff) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+func (l *MemoryLimiter) Name() string {
+	return "ratelimit-memory"
+}
+
+func (l *MemoryLimiter) Running() bool {
+	return l.running.Load()
+}
@@ -0,0 +1,69 @@
+package ratelimit
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// PostgresLimiter stores buckets in Postgres so limits hold across replicas.
+type PostgresLimiter struct {
+	repo    repository.RateLimitRepository
+	idleTTL time.Duration
+	log     *zap.Logger
+
+	running atomic.Bool
+}
+
+func NewPostgresLimiter(repo repository.RateLimitRepository, idleTTL time.Duration, log *zap.Logger) *PostgresLimiter {
+	return &PostgresLimiter{
+		repo:    repo,
+		idleTTL: idleTTL,
+		log:     log,
+	}
+}
+
+func (l *PostgresLimiter) Allow(ctx context.Context, key string, limit Limit) (Decision, error) {
+	allowed, tokens, err := l.repo.Take(ctx, key, float64(limit.Burst), limit.Rate)
+	if err != nil {
+		return Decision{}, err
+	}
+
+	return decide(allowed, tokens, limit), nil
+}
+
+// Run deletes idle buckets until ctx is done.
+func (l *PostgresLimiter) Run(ctx context.Context) {
+	l.running.Store(true)
+	defer l.running.Store(false)
+
+	ticker := time.NewTicker(l.idleTTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			deleted, err := l.repo.DeleteIdle(logger.WithContext(ctx, l.log), time.Now().Add(-l.idleTTL))
+			if err != nil {
+				l.log.Warn("failed to delete idle rate limit buckets", zap.Error(err))
+				continue
+			}
+			l.log.Debug("deleted idle rate limit buckets", zap.Int64("count", deleted))
+		}
+	}
+}
+
+func (l *PostgresLimiter) Name() string {
+	return "ratelimit-postgres"
+}
+
+func (l *PostgresLimiter) Running() bool {
+	return l.running.Load()
+}
@@ -0,0 +1,36 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Limit is a token bucket refilled at Rate tokens per second up to Burst tokens.
+type Limit struct {
+	Rate  float64
+	Burst int
+}
+
+type Decision struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string, limit Limit) (Decision, error)
+}
+
+// decide turns the number of tokens left in a bucket after a take attempt into a decision.
+func decide(allowed bool, tokens float64, limit Limit) Decision {
+	if allowed {
+		return Decision{Allowed: true}
+	}
+
+	wait := time.Second
+	if limit.Rate > 0 {
+		wait = time.Duration(math.Ceil((1 - tokens) / limit.Rate * float64(time.Second)))
+	}
+
+	return Decision{Allowed: false, RetryAfter: wait}
+}
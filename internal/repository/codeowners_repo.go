@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type CodeownersRepository interface {
+	Get(ctx context.Context, teamName string) (*domain.Codeowners, error)
+	Save(ctx context.Context, c *domain.Codeowners) error
+}
+
+type codeownersRepository struct {
+	db querier
+}
+
+func NewCodeownersRepository(db *sql.DB) CodeownersRepository {
+	return &codeownersRepository{db: newTracedDB(db)}
+}
+
+func (r *codeownersRepository) Get(ctx context.Context, teamName string) (*domain.Codeowners, error) {
+	const q = `
+	SELECT team_name, content, updated_at
+	FROM team_codeowners
+	WHERE team_name = $1
+	`
+
+	var c domain.Codeowners
+	err := r.db.QueryRowContext(ctx, q, teamName).Scan(&c.TeamName, &c.Content, &c.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &c, nil
+}
+
+// Save replaces the team's CODEOWNERS file.
+func (r *codeownersRepository) Save(ctx context.Context, c *domain.Codeowners) error {
+	const q = `
+	INSERT INTO team_codeowners (team_name, content, updated_at)
+	VALUES ($1, $2, $3)
+	ON CONFLICT (team_name) DO UPDATE
+	SET content = EXCLUDED.content, updated_at = EXCLUDED.updated_at
+	`
+
+	_, err := r.db.ExecContext(ctx, q, c.TeamName, c.Content, c.UpdatedAt)
+	return err
+}
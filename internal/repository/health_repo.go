@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// SchemaVersion is the latest migration version the code expects to find in schema_migrations.
+const SchemaVersion = 20
+
+type HealthRepository interface {
+	Ping(ctx context.Context) error
+	SchemaVersion(ctx context.Context) (int, error)
+}
+
+type healthRepository struct {
+	db *sql.DB
+}
+
+func NewHealthRepository(db *sql.DB) HealthRepository {
+	return &healthRepository{db: db}
+}
+
+func (r *healthRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+func (r *healthRepository) SchemaVersion(ctx context.Context) (int, error) {
+	const q = `
+	SELECT COALESCE(MAX(version), 0)
+	FROM schema_migrations
+	`
+
+	var version int
+	err := r.db.QueryRowContext(ctx, q).Scan(&version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	return version, nil
+}
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type IdempotencyRepository interface {
+	// Reserve claims key for a new request. When the key is already taken and
+	// not expired, the existing record is returned with reserved set to false.
+	Reserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (existing *domain.IdempotencyRecord, reserved bool, err error)
+	Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error
+	Release(ctx context.Context, scope, key string) error
+	DeleteExpired(ctx context.Context) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db querier
+}
+
+func NewIdempotencyRepository(db *sql.DB) IdempotencyRepository {
+	return &idempotencyRepository{db: newTracedDB(db)}
+}
+
+const reserveAttempts = 3
+
+func (r *idempotencyRepository) Reserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (*domain.IdempotencyRecord, bool, error) {
+	// The existing row can be released or cleaned up between the insert and
+	// the select; the key is then free and the insert is tried again.
+	for range reserveAttempts {
+		reserved, err := r.tryReserve(ctx, scope, key, requestHash, ttl)
+		if err != nil || reserved {
+			return nil, reserved, err
+		}
+
+		rec, err := r.get(ctx, scope, key)
+		if errors.Is(err, ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, false, err
+		}
+		return rec, false, nil
+	}
+
+	return nil, false, fmt.Errorf("can't reserve idempotency key after %d attempts", reserveAttempts)
+}
+
+func (r *idempotencyRepository) tryReserve(ctx context.Context, scope, key, requestHash string, ttl time.Duration) (bool, error) {
+	const q = `
+	INSERT INTO idempotency_keys (scope, idempotency_key, request_hash, created_at, expires_at)
+	VALUES ($1, $2, $3, now(), now() + make_interval(secs => $4))
+	ON CONFLICT (scope, idempotency_key) DO UPDATE SET
+		request_hash = EXCLUDED.request_hash,
+		status_code = NULL,
+		content_type = NULL,
+		response_body = NULL,
+		created_at = EXCLUDED.created_at,
+		expires_at = EXCLUDED.expires_at
+	WHERE idempotency_keys.expires_at < now()
+	RETURNING TRUE
+	`
+
+	var reserved bool
+	err := r.db.QueryRowContext(ctx, q, scope, key, requestHash, ttl.Seconds()).Scan(&reserved)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (r *idempotencyRepository) get(ctx context.Context, scope, key string) (*domain.IdempotencyRecord, error) {
+	const q = `
+	SELECT scope, idempotency_key, request_hash, COALESCE(status_code, 0), COALESCE(content_type, ''), response_body, created_at, expires_at
+	FROM idempotency_keys
+	WHERE scope = $1 AND idempotency_key = $2
+	`
+
+	var rec domain.IdempotencyRecord
+	err := r.db.QueryRowContext(ctx, q, scope, key).Scan(
+		&rec.Scope,
+		&rec.Key,
+		&rec.RequestHash,
+		&rec.StatusCode,
+		&rec.ContentType,
+		&rec.ResponseBody,
+		&rec.CreatedAt,
+		&rec.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &rec, nil
+}
+
+func (r *idempotencyRepository) Complete(ctx context.Context, scope, key string, status int, contentType string, body []byte) error {
+	const q = `
+	UPDATE idempotency_keys
+	SET status_code = $3, content_type = $4, response_body = $5
+	WHERE scope = $1 AND idempotency_key = $2
+	`
+
+	res, err := r.db.ExecContext(ctx, q, scope, key, status, contentType, body)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *idempotencyRepository) Release(ctx context.Context, scope, key string) error {
+	const q = `
+	DELETE FROM idempotency_keys
+	WHERE scope = $1 AND idempotency_key = $2
+	`
+
+	_, err := r.db.ExecContext(ctx, q, scope, key)
+	return err
+}
+
+func (r *idempotencyRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	const q = `
+	DELETE FROM idempotency_keys
+	WHERE expires_at < now()
+	`
+
+	res, err := r.db.ExecContext(ctx, q)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -4,35 +4,49 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/lib/pq"
+	"go.uber.org/zap"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 )
 
 type PRRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.PullRequest, error)
 	Create(ctx context.Context, pr *domain.PullRequest) error
 	Update(ctx context.Context, pr *domain.PullRequest) error
-	ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)
+	List(ctx context.Context, filter domain.PRListFilter) ([]domain.PullRequestShort, error)
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*domain.PullRequest, error)
+	CreateEvent(ctx context.Context, event *domain.PREvent) error
+	CreateDecision(ctx context.Context, decision *domain.AssignmentDecision) error
+	// ListDecisions returns the assignment decisions of a pull request, oldest first.
+	ListDecisions(ctx context.Context, pullRequestID string) ([]domain.AssignmentDecision, error)
 
 	CountAll(ctx context.Context) (int, error)
 	CountByStatus(ctx context.Context, status domain.PRStatus) (int, error)
+	// CountAssignmentsByReviewer returns the assigned and the declined reviews
+	// of every user, ordered by user_id.
 	CountAssignmentsByReviewer(ctx context.Context) ([]domain.UserReviewStat, error)
+	// CountOpenReviews returns the number of open pull requests each of userIDs
+	// reviews. Users without open reviews are absent from the map.
+	CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error)
 }
 
 type prRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewPRRepository(db *sql.DB) PRRepository {
-	return &prRepository{db: db}
+	return &prRepository{db: newTracedDB(db)}
 }
 
 func (r *prRepository) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
 	const q = `
-	SELECT pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at
+	SELECT pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at, COALESCE(merged_by, ''), required_tags, shadow_reviewers
 	FROM pull_requests
 	WHERE pull_request_id = $1
 	`
@@ -47,6 +61,9 @@ func (r *prRepository) GetByID(ctx context.Context, id string) (*domain.PullRequ
 		pq.Array(&pr.Reviewers),
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.MergedBy,
+		pq.Array(&pr.RequiredTags),
+		pq.Array(&pr.ShadowReviewers),
 	)
 
 	if err != nil {
@@ -61,8 +78,8 @@ func (r *prRepository) GetByID(ctx context.Context, id string) (*domain.PullRequ
 
 func (r *prRepository) Create(ctx context.Context, pr *domain.PullRequest) error {
 	const q = `
-	INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at)
-	VALUES ($1, $2, $3, $4, $5, $6)
+	INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at, merged_by, required_tags, shadow_reviewers)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''), COALESCE($9::TEXT[], '{}'), COALESCE($10::TEXT[], '{}'))
 	`
 
 	_, err := r.db.ExecContext(ctx, q,
@@ -72,6 +89,10 @@ func (r *prRepository) Create(ctx context.Context, pr *domain.PullRequest) error
 		pr.Status,
 		pq.Array(pr.Reviewers),
 		pr.CreatedAt,
+		pr.MergedAt,
+		pr.MergedBy,
+		pq.Array(pr.RequiredTags),
+		pq.Array(pr.ShadowReviewers),
 	)
 	if err != nil {
 		return err
@@ -83,7 +104,8 @@ func (r *prRepository) Create(ctx context.Context, pr *domain.PullRequest) error
 func (r *prRepository) Update(ctx context.Context, pr *domain.PullRequest) error {
 	const q = `
 	UPDATE pull_requests
-	SET status = $2, assigned_reviewers = $3, merged_at = $4
+	SET status = $2, assigned_reviewers = $3, merged_at = $4, merged_by = NULLIF($5, ''),
+	    shadow_reviewers = COALESCE($6::TEXT[], '{}')
 	WHERE pull_request_id = $1
 	`
 
@@ -92,6 +114,8 @@ func (r *prRepository) Update(ctx context.Context, pr *domain.PullRequest) error
 		pr.Status,
 		pq.Array(pr.Reviewers),
 		pr.MergedAt,
+		pr.MergedBy,
+		pq.Array(pr.ShadowReviewers),
 	)
 	if err != nil {
 		return err
@@ -108,45 +132,242 @@ func (r *prRepository) Update(ctx context.Context, pr *domain.PullRequest) error
 	return nil
 }
 
-func (r *prRepository) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
-	const q = `
-	SELECT pull_request_id, pull_request_name, author_id, status
-	FROM pull_requests
-	WHERE $1 = ANY(assigned_reviewers)
-	`
+// List returns up to filter.Limit pull requests matching filter in keyset order.
+func (r *prRepository) List(ctx context.Context, filter domain.PRListFilter) ([]domain.PullRequestShort, error) {
+	var (
+		conds []string
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	// role tells reviewers and shadow reviewers apart when listing by reviewer.
+	role := "''"
+	if filter.ReviewerID != "" {
+		reviewer := arg(filter.ReviewerID)
+		conds = append(conds, fmt.Sprintf("(%[1]s = ANY(assigned_reviewers) OR %[1]s = ANY(shadow_reviewers))", reviewer))
+		role = fmt.Sprintf("CASE WHEN %s = ANY(assigned_reviewers) THEN '%s' ELSE '%s' END",
+			reviewer, domain.ReviewerRoleReviewer, domain.ReviewerRoleShadow)
+	}
+	if filter.AuthorID != "" {
+		conds = append(conds, "author_id = "+arg(filter.AuthorID))
+	}
+	if filter.TeamName != "" {
+		conds = append(conds, "author_id IN (SELECT user_id FROM users WHERE team_name = "+arg(filter.TeamName)+")")
+	}
+	if filter.Status != "" {
+		conds = append(conds, "status = "+arg(filter.Status))
+	}
+	if filter.CreatedAfter != nil {
+		conds = append(conds, "created_at > "+arg(*filter.CreatedAfter))
+	}
+
+	cmp, order := ">", "ASC"
+	if filter.Sort == domain.SortDesc {
+		cmp, order = "<", "DESC"
+	}
+	if filter.After != nil {
+		conds = append(conds, fmt.Sprintf("(created_at, pull_request_id) %s (%s, %s)",
+			cmp, arg(filter.After.CreatedAt), arg(filter.After.ID)))
+	}
 
-	rows, err := r.db.QueryContext(ctx, q, reviewerID)
+	q := `
+	SELECT pull_request_id, pull_request_name, author_id, status, created_at, ` + role + `
+	FROM pull_requests`
+	if len(conds) > 0 {
+		q += `
+	WHERE ` + strings.Join(conds, " AND ")
+	}
+	q += fmt.Sprintf(`
+	ORDER BY created_at %[1]s, pull_request_id %[1]s`, order)
+	if filter.Limit > 0 {
+		q += `
+	LIMIT ` + arg(filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
 	if err != nil {
 		return nil, err
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
-			log.Println("failed to close rows:", err)
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
 		}
 	}()
 
-	var result []domain.PullRequestShort
+	result := make([]domain.PullRequestShort, 0, filter.Limit)
 
 	for rows.Next() {
-		item := domain.PullRequestShort{}
+		var (
+			item      domain.PullRequestShort
+			createdAt time.Time
+		)
 		if err := rows.Scan(
 			&item.ID,
 			&item.Name,
 			&item.AuthorID,
 			&item.Status,
+			&createdAt,
+			&item.Role,
 		); err != nil {
 			return nil, err
 		}
+		item.CreatedAt = &createdAt
 		result = append(result, item)
 	}
 
-	err = rows.Err()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListAfter returns up to limit pull requests with pull_request_id greater than afterID, ordered by pull_request_id.
+func (r *prRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*domain.PullRequest, error) {
+	const q = `
+	SELECT pull_request_id, pull_request_name, author_id, status, assigned_reviewers, created_at, merged_at, COALESCE(merged_by, ''), required_tags, shadow_reviewers
+	FROM pull_requests
+	WHERE pull_request_id > $1
+	ORDER BY pull_request_id
+	LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, afterID, limit)
 	if err != nil {
 		return nil, err
 	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
 
-	if len(result) == 0 {
-		return []domain.PullRequestShort{}, nil
+	result := make([]*domain.PullRequest, 0, limit)
+
+	for rows.Next() {
+		pr := &domain.PullRequest{}
+		if err := rows.Scan(
+			&pr.ID,
+			&pr.Name,
+			&pr.AuthorID,
+			&pr.Status,
+			pq.Array(&pr.Reviewers),
+			&pr.CreatedAt,
+			&pr.MergedAt,
+			&pr.MergedBy,
+			pq.Array(&pr.RequiredTags),
+			pq.Array(&pr.ShadowReviewers),
+		); err != nil {
+			return nil, err
+		}
+		result = append(result, pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *prRepository) CreateEvent(ctx context.Context, event *domain.PREvent) error {
+	const q = `
+	INSERT INTO pull_request_events (pull_request_id, event_type, actor, old_reviewer_id, new_reviewer_id, created_at, reason)
+	VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''))
+	RETURNING event_id
+	`
+
+	return r.db.QueryRowContext(ctx, q,
+		event.PullRequestID,
+		event.Type,
+		event.Actor,
+		event.OldReviewerID,
+		event.NewReviewerID,
+		event.CreatedAt,
+		event.Reason,
+	).Scan(&event.ID)
+}
+
+func (r *prRepository) CreateDecision(ctx context.Context, decision *domain.AssignmentDecision) error {
+	const q = `
+	INSERT INTO assignment_decisions (pull_request_id, kind, strategy, seed, candidates, excluded, exclusion_reasons, selected, actor, created_at)
+	VALUES ($1, $2, $3, $4, COALESCE($5::TEXT[], '{}'), COALESCE($6::TEXT[], '{}'), COALESCE($7::TEXT[], '{}'), COALESCE($8::TEXT[], '{}'), $9, $10)
+	RETURNING decision_id
+	`
+
+	excluded := make([]string, 0, len(decision.Exclusions))
+	reasons := make([]string, 0, len(decision.Exclusions))
+	for _, e := range decision.Exclusions {
+		excluded = append(excluded, e.UserID)
+		reasons = append(reasons, string(e.Reason))
+	}
+
+	return r.db.QueryRowContext(ctx, q,
+		decision.PullRequestID,
+		decision.Kind,
+		decision.Strategy,
+		decision.Seed,
+		pq.Array(decision.Candidates),
+		pq.Array(excluded),
+		pq.Array(reasons),
+		pq.Array(decision.Selected),
+		decision.Actor,
+		decision.CreatedAt,
+	).Scan(&decision.ID)
+}
+
+func (r *prRepository) ListDecisions(ctx context.Context, pullRequestID string) ([]domain.AssignmentDecision, error) {
+	const q = `
+	SELECT decision_id, pull_request_id, kind, strategy, seed, candidates, excluded, exclusion_reasons, selected, actor, created_at
+	FROM assignment_decisions
+	WHERE pull_request_id = $1
+	ORDER BY decision_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := []domain.AssignmentDecision{}
+
+	for rows.Next() {
+		var (
+			d                 domain.AssignmentDecision
+			excluded, reasons []string
+		)
+		if err := rows.Scan(
+			&d.ID,
+			&d.PullRequestID,
+			&d.Kind,
+			&d.Strategy,
+			&d.Seed,
+			pq.Array(&d.Candidates),
+			pq.Array(&excluded),
+			pq.Array(&reasons),
+			pq.Array(&d.Selected),
+			&d.Actor,
+			&d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		d.Exclusions = make([]domain.Exclusion, 0, len(excluded))
+		for i, id := range excluded {
+			d.Exclusions = append(d.Exclusions, domain.Exclusion{UserID: id, Reason: domain.ExclusionReason(reasons[i])})
+		}
+		result = append(result, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
 	}
 
 	return result, nil
@@ -185,10 +406,21 @@ func (r *prRepository) CountByStatus(ctx context.Context, status domain.PRStatus
 
 func (r *prRepository) CountAssignmentsByReviewer(ctx context.Context) ([]domain.UserReviewStat, error) {
 	const q = `
-	SELECT reviewer_id, COUNT(*)
-	FROM pull_requests
-	CROSS JOIN LATERAL unnest(assigned_reviewers) AS reviewer_id
-	GROUP BY reviewer_id
+	WITH assigned AS (
+		SELECT reviewer_id, COUNT(*) AS reviews
+		FROM pull_requests
+		CROSS JOIN LATERAL unnest(assigned_reviewers) AS reviewer_id
+		GROUP BY reviewer_id
+	), declined AS (
+		SELECT old_reviewer_id AS reviewer_id, COUNT(*) AS declines
+		FROM pull_request_events
+		WHERE event_type = 'DECLINED'
+		GROUP BY old_reviewer_id
+	)
+	SELECT reviewer_id, COALESCE(reviews, 0), COALESCE(declines, 0)
+	FROM assigned
+	FULL JOIN declined USING (reviewer_id)
+	ORDER BY reviewer_id
 	`
 
 	rows, err := r.db.QueryContext(ctx, q)
@@ -197,7 +429,7 @@ func (r *prRepository) CountAssignmentsByReviewer(ctx context.Context) ([]domain
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
-			log.Println("failed to close rows:", err)
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
 		}
 	}()
 
@@ -205,7 +437,7 @@ func (r *prRepository) CountAssignmentsByReviewer(ctx context.Context) ([]domain
 
 	for rows.Next() {
 		var s domain.UserReviewStat
-		if err := rows.Scan(&s.UserID, &s.ReviewsCount); err != nil {
+		if err := rows.Scan(&s.UserID, &s.ReviewsCount, &s.DeclinedCount); err != nil {
 			return nil, err
 		}
 		result = append(result, s)
@@ -221,3 +453,45 @@ func (r *prRepository) CountAssignmentsByReviewer(ctx context.Context) ([]domain
 
 	return result, nil
 }
+
+func (r *prRepository) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	const q = `
+	SELECT reviewer_id, COUNT(*)
+	FROM pull_requests
+	CROSS JOIN LATERAL unnest(assigned_reviewers) AS reviewer_id
+	WHERE status = $1 AND assigned_reviewers && $2 AND reviewer_id = ANY($2)
+	GROUP BY reviewer_id
+	`
+
+	result := make(map[string]int)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, domain.PRStatusOpen, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		var (
+			id    string
+			count int
+		)
+		if err := rows.Scan(&id, &count); err != nil {
+			return nil, err
+		}
+		result[id] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+type RateLimitRepository interface {
+	// Take refills the bucket for key and removes one token if available.
+	Take(ctx context.Context, key string, capacity, ratePerSecond float64) (allowed bool, tokens float64, err error)
+	DeleteIdle(ctx context.Context, before time.Time) (int64, error)
+}
+
+type rateLimitRepository struct {
+	db querier
+}
+
+func NewRateLimitRepository(db *sql.DB) RateLimitRepository {
+	return &rateLimitRepository{db: newTracedDB(db)}
+}
+
+func (r *rateLimitRepository) Take(ctx context.Context, key string, capacity, ratePerSecond float64) (bool, float64, error) {
+	// The refill is computed from the stored row inside the upsert, so
+	// concurrent requests from different replicas are serialized by the row lock.
+	const q = `
+	INSERT INTO rate_limit_buckets AS b (bucket_key, tokens, allowed, updated_at)
+	VALUES ($1, $2::float8 - 1, TRUE, now())
+	ON CONFLICT (bucket_key) DO UPDATE SET
+		allowed = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) >= 1,
+		tokens = LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8)
+			- CASE WHEN LEAST($2::float8, b.tokens + EXTRACT(EPOCH FROM now() - b.updated_at)::float8 * $3::float8) >= 1 THEN 1 ELSE 0 END,
+		updated_at = now()
+	RETURNING allowed, tokens
+	`
+
+	var (
+		allowed bool
+		tokens  float64
+	)
+	err := r.db.QueryRowContext(ctx, q, key, capacity, ratePerSecond).Scan(&allowed, &tokens)
+	if err != nil {
+		return false, 0, err
+	}
+
+	return allowed, tokens, nil
+}
+
+func (r *rateLimitRepository) DeleteIdle(ctx context.Context, before time.Time) (int64, error) {
+	const q = `
+	DELETE FROM rate_limit_buckets
+	WHERE updated_at < $1
+	`
+
+	res, err := r.db.ExecContext(ctx, q, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+)
+
+type ReviewRulesRepository interface {
+	// Get returns the rules of the team; a team without rules gets empty ones.
+	Get(ctx context.Context, teamName string) (*domain.ReviewRules, error)
+	// Save replaces all rules of the team.
+	Save(ctx context.Context, rules *domain.ReviewRules) error
+}
+
+type reviewRulesRepository struct {
+	db querier
+}
+
+func NewReviewRulesRepository(db *sql.DB) ReviewRulesRepository {
+	return &reviewRulesRepository{db: newTracedDB(db)}
+}
+
+func (r *reviewRulesRepository) Get(ctx context.Context, teamName string) (*domain.ReviewRules, error) {
+	rules := &domain.ReviewRules{
+		TeamName:      teamName,
+		AlwaysInclude: []domain.MandatoryReviewer{},
+		NeverPair:     []domain.NeverPair{},
+	}
+
+	err := r.query(ctx, `
+	SELECT user_id, paths
+	FROM team_mandatory_reviewers
+	WHERE team_name = $1
+	ORDER BY user_id
+	`, teamName, func(rows *sql.Rows) error {
+		var m domain.MandatoryReviewer
+		if err := rows.Scan(&m.UserID, pq.Array(&m.Paths)); err != nil {
+			return err
+		}
+		rules.AlwaysInclude = append(rules.AlwaysInclude, m)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.query(ctx, `
+	SELECT author_id, reviewer_id
+	FROM team_never_pairs
+	WHERE team_name = $1
+	ORDER BY author_id, reviewer_id
+	`, teamName, func(rows *sql.Rows) error {
+		var p domain.NeverPair
+		if err := rows.Scan(&p.AuthorID, &p.ReviewerID); err != nil {
+			return err
+		}
+		rules.NeverPair = append(rules.NeverPair, p)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.query(ctx, `
+	SELECT require_senior
+	FROM teams
+	WHERE team_name = $1
+	`, teamName, func(rows *sql.Rows) error {
+		return rows.Scan(&rules.RequireSenior)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (r *reviewRulesRepository) query(ctx context.Context, q, teamName string, scan func(rows *sql.Rows) error) error {
+	rows, err := r.db.QueryContext(ctx, q, teamName)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+func (r *reviewRulesRepository) Save(ctx context.Context, rules *domain.ReviewRules) error {
+	for _, table := range []string{"team_mandatory_reviewers", "team_never_pairs"} {
+		if _, err := r.db.ExecContext(ctx, `DELETE FROM `+table+` WHERE team_name = $1`, rules.TeamName); err != nil {
+			return err
+		}
+	}
+
+	for _, m := range rules.AlwaysInclude {
+		const q = `
+		INSERT INTO team_mandatory_reviewers (team_name, user_id, paths)
+		VALUES ($1, $2, COALESCE($3::TEXT[], '{}'))
+		`
+		if _, err := r.db.ExecContext(ctx, q, rules.TeamName, m.UserID, pq.Array(m.Paths)); err != nil {
+			return err
+		}
+	}
+
+	for _, p := range rules.NeverPair {
+		const q = `
+		INSERT INTO team_never_pairs (team_name, author_id, reviewer_id)
+		VALUES ($1, $2, $3)
+		`
+		if _, err := r.db.ExecContext(ctx, q, rules.TeamName, p.AuthorID, p.ReviewerID); err != nil {
+			return err
+		}
+	}
+
+	const q = `UPDATE teams SET require_senior = $2 WHERE team_name = $1`
+	if _, err := r.db.ExecContext(ctx, q, rules.TeamName, rules.RequireSenior); err != nil {
+		return err
+	}
+
+	return nil
+}
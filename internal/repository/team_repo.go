@@ -5,32 +5,38 @@ import (
 	"database/sql"
 	"errors"
 
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 )
 
 type TeamRepository interface {
 	GetByName(ctx context.Context, name string) (*domain.Team, error)
 	Create(ctx context.Context, team *domain.Team) error
+	Update(ctx context.Context, team *domain.Team) error
+	List(ctx context.Context) ([]*domain.Team, error)
 }
 
 type teamRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewTeamRepository(db *sql.DB) TeamRepository {
-	return &teamRepository{db: db}
+	return &teamRepository{db: newTracedDB(db)}
 }
 
 func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Team, error) {
 	const q = `
-	SELECT team_name
+	SELECT team_name, capacity_policy, partner_teams, mentoring
 	FROM teams
 	WHERE team_name = $1
 	`
 
 	var team domain.Team
 
-	err := r.db.QueryRowContext(ctx, q, name).Scan(&team.Name)
+	err := r.db.QueryRowContext(ctx, q, name).Scan(&team.Name, &team.CapacityPolicy, pq.Array(&team.PartnerTeams), &team.Mentoring)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -43,14 +49,73 @@ func (r *teamRepository) GetByName(ctx context.Context, name string) (*domain.Te
 
 func (r *teamRepository) Create(ctx context.Context, team *domain.Team) error {
 	const q = `
-	INSERT INTO teams (team_name)
-	VALUES ($1)
+	INSERT INTO teams (team_name, capacity_policy, partner_teams, mentoring)
+	VALUES ($1, COALESCE(NULLIF($2, ''), 'reject'), COALESCE($3::TEXT[], '{}'), $4)
+	`
+
+	_, err := r.db.ExecContext(ctx, q, team.Name, team.CapacityPolicy, pq.Array(team.PartnerTeams), team.Mentoring)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *teamRepository) Update(ctx context.Context, team *domain.Team) error {
+	const q = `
+	UPDATE teams
+	SET capacity_policy = $2,
+	    partner_teams = COALESCE($3::TEXT[], '{}'),
+	    mentoring = $4
+	WHERE team_name = $1
 	`
 
-	_, err := r.db.ExecContext(ctx, q, team.Name)
+	res, err := r.db.ExecContext(ctx, q, team.Name, team.CapacityPolicy, pq.Array(team.PartnerTeams), team.Mentoring)
 	if err != nil {
 		return err
 	}
 
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
 	return nil
 }
+
+func (r *teamRepository) List(ctx context.Context) ([]*domain.Team, error) {
+	const q = `
+	SELECT team_name, capacity_policy, partner_teams, mentoring
+	FROM teams
+	ORDER BY team_name
+	`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	var result []*domain.Team
+
+	for rows.Next() {
+		team := &domain.Team{}
+		if err := rows.Scan(&team.Name, &team.CapacityPolicy, pq.Array(&team.PartnerTeams), &team.Mentoring); err != nil {
+			return nil, err
+		}
+		result = append(result, team)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
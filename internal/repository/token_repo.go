@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type TokenRepository interface {
+	GetByHash(ctx context.Context, hash string) (*domain.APIToken, error)
+	Create(ctx context.Context, token *domain.APIToken) error
+	Revoke(ctx context.Context, id string) error
+	// RevokeByUser revokes every active token issued for userID and returns
+	// how many were revoked.
+	RevokeByUser(ctx context.Context, userID string) (int, error)
+}
+
+type tokenRepository struct {
+	db querier
+}
+
+func NewTokenRepository(db *sql.DB) TokenRepository {
+	return &tokenRepository{db: newTracedDB(db)}
+}
+
+func (r *tokenRepository) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	const q = `
+	SELECT token_id, name, role, COALESCE(team_name, ''), COALESCE(user_id, ''), token_hash, created_at, revoked_at
+	FROM api_tokens
+	WHERE token_hash = $1
+	`
+
+	var t domain.APIToken
+
+	err := r.db.QueryRowContext(ctx, q, hash).Scan(
+		&t.ID,
+		&t.Name,
+		&t.Role,
+		&t.TeamName,
+		&t.UserID,
+		&t.Hash,
+		&t.CreatedAt,
+		&t.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &t, nil
+}
+
+func (r *tokenRepository) Create(ctx context.Context, token *domain.APIToken) error {
+	const q = `
+	INSERT INTO api_tokens (token_id, name, role, team_name, token_hash, created_at, user_id)
+	VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, NULLIF($7, ''))
+	`
+
+	_, err := r.db.ExecContext(ctx, q,
+		token.ID,
+		token.Name,
+		token.Role,
+		token.TeamName,
+		token.Hash,
+		token.CreatedAt,
+		token.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *tokenRepository) Revoke(ctx context.Context, id string) error {
+	const q = `
+	UPDATE api_tokens
+	SET revoked_at = now()
+	WHERE token_id = $1 AND revoked_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *tokenRepository) RevokeByUser(ctx context.Context, userID string) (int, error) {
+	const q = `
+	UPDATE api_tokens
+	SET revoked_at = now()
+	WHERE user_id = $1 AND revoked_at IS NULL
+	`
+
+	res, err := r.db.ExecContext(ctx, q, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(rows), nil
+}
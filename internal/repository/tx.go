@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Transactor runs a function inside a database transaction. Repositories
+// called with the context passed to fn execute their statements in it.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinSnapshot runs fn in a read-only REPEATABLE READ transaction, so
+	// that every query in fn sees the same snapshot of the database.
+	WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txKey struct{}
+
+type transactor struct {
+	db *sql.DB
+}
+
+func NewTransactor(db *sql.DB) Transactor {
+	return &transactor{db: db}
+}
+
+// WithinTx commits when fn returns nil and rolls back otherwise. Nested calls
+// reuse the outer transaction.
+func (t *transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.within(ctx, nil, fn)
+}
+
+func (t *transactor) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.within(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}, fn)
+}
+
+func (t *transactor) within(ctx context.Context, opts *sql.TxOptions, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := t.db.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("can't begin transaction: %w", err)
+	}
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("can't commit transaction: %w", err)
+	}
+
+	return nil
+}
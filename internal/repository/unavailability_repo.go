@@ -0,0 +1,192 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+)
+
+type UnavailabilityRepository interface {
+	Create(ctx context.Context, u *domain.Unavailability) error
+	GetByID(ctx context.Context, id int64) (*domain.Unavailability, error)
+	Delete(ctx context.Context, id int64) error
+	ListByUser(ctx context.Context, userID string, endsAfter time.Time) ([]domain.Unavailability, error)
+	// ListAfter returns up to limit windows of every user ordered by id.
+	ListAfter(ctx context.Context, afterID int64, limit int) ([]domain.Unavailability, error)
+	// UnavailableAt returns which of userIDs have a window covering at.
+	UnavailableAt(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error)
+}
+
+type unavailabilityRepository struct {
+	db querier
+}
+
+func NewUnavailabilityRepository(db *sql.DB) UnavailabilityRepository {
+	return &unavailabilityRepository{db: newTracedDB(db)}
+}
+
+func (r *unavailabilityRepository) Create(ctx context.Context, u *domain.Unavailability) error {
+	const q = `
+	INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, created_at)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING unavailability_id
+	`
+
+	return r.db.QueryRowContext(ctx, q, u.UserID, u.StartsAt, u.EndsAt, u.Reason, u.CreatedAt).Scan(&u.ID)
+}
+
+func (r *unavailabilityRepository) GetByID(ctx context.Context, id int64) (*domain.Unavailability, error) {
+	const q = `
+	SELECT unavailability_id, user_id, starts_at, ends_at, reason, created_at
+	FROM user_unavailability
+	WHERE unavailability_id = $1
+	`
+
+	var u domain.Unavailability
+	err := r.db.QueryRowContext(ctx, q, id).Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func (r *unavailabilityRepository) Delete(ctx context.Context, id int64) error {
+	const q = `
+	DELETE FROM user_unavailability
+	WHERE unavailability_id = $1
+	`
+
+	res, err := r.db.ExecContext(ctx, q, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *unavailabilityRepository) ListByUser(ctx context.Context, userID string, endsAfter time.Time) ([]domain.Unavailability, error) {
+	const q = `
+	SELECT unavailability_id, user_id, starts_at, ends_at, reason, created_at
+	FROM user_unavailability
+	WHERE user_id = $1 AND ends_at > $2
+	ORDER BY starts_at, unavailability_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, userID, endsAfter)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := []domain.Unavailability{}
+
+	for rows.Next() {
+		var u domain.Unavailability
+		if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *unavailabilityRepository) ListAfter(ctx context.Context, afterID int64, limit int) ([]domain.Unavailability, error) {
+	const q = `
+	SELECT unavailability_id, user_id, starts_at, ends_at, reason, created_at
+	FROM user_unavailability
+	WHERE unavailability_id > $1
+	ORDER BY unavailability_id
+	LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := []domain.Unavailability{}
+
+	for rows.Next() {
+		var u domain.Unavailability
+		if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+		result = append(result, u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *unavailabilityRepository) UnavailableAt(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	const q = `
+	SELECT DISTINCT user_id
+	FROM user_unavailability
+	WHERE user_id = ANY($1) AND starts_at <= $2 AND ends_at > $2
+	`
+
+	result := make(map[string]bool)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(userIDs), at)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		result[id] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -4,42 +4,64 @@ import (
 	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+	"go.uber.org/zap"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 )
 
 type UserRepository interface {
 	GetByID(ctx context.Context, id string) (*domain.User, error)
 	Update(ctx context.Context, user *domain.User) error
 	Create(ctx context.Context, user *domain.User) error
+	// ListActiveByTeam and ListByIDs order users by user_id, so that a seeded
+	// reviewer selection over them can be replayed.
 	ListActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error)
+	ListByIDs(ctx context.Context, ids []string) ([]*domain.User, error)
+	ListAfter(ctx context.Context, afterID string, limit int) ([]*domain.User, error)
+	List(ctx context.Context, filter domain.UserListFilter) ([]domain.User, error)
+
+	ListTags(ctx context.Context) ([]domain.Tag, error)
+	CreateTag(ctx context.Context, tag *domain.Tag) error
+	// SetTags replaces the expertise tags of the user.
+	SetTags(ctx context.Context, userID string, tags []string) error
+	// TagsByUsers returns the tags of each of userIDs; users without tags are absent.
+	TagsByUsers(ctx context.Context, userIDs []string) (map[string][]string, error)
+}
+
+// userColumns lists the columns read by scanUser, in order.
+const userColumns = "user_id, username, team_name, is_active, deleted_at, max_open_reviews, seniority"
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner, u *domain.User) error {
+	return row.Scan(&u.ID, &u.Username, &u.TeamName, &u.IsActive, &u.DeletedAt, &u.MaxOpenReviews, &u.Seniority)
 }
 
 type userRepository struct {
-	db *sql.DB
+	db querier
 }
 
 func NewUserRepository(db *sql.DB) UserRepository {
-	return &userRepository{db: db}
+	return &userRepository{db: newTracedDB(db)}
 }
 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User, error) {
-	const q = `
-	SELECT user_id, username, team_name, is_active
+	q := `
+	SELECT ` + userColumns + `
 	FROM users
 	WHERE user_id = $1
 	`
 
 	var u domain.User
 
-	err := r.db.QueryRowContext(ctx, q, id).Scan(
-		&u.ID,
-		&u.Username,
-		&u.TeamName,
-		&u.IsActive,
-	)
-
+	err := scanUser(r.db.QueryRowContext(ctx, q, id), &u)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, ErrNotFound
@@ -53,11 +75,12 @@ func (r *userRepository) GetByID(ctx context.Context, id string) (*domain.User,
 func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 	const q = `
 	UPDATE users
-	SET username = $2, team_name = $3, is_active = $4
+	SET username = $2, team_name = $3, is_active = $4, deleted_at = $5, max_open_reviews = $6,
+	    seniority = COALESCE(NULLIF($7, ''), seniority)
 	WHERE user_id = $1
 	`
 
-	res, err := r.db.ExecContext(ctx, q, user.ID, user.Username, user.TeamName, user.IsActive)
+	res, err := r.db.ExecContext(ctx, q, user.ID, user.Username, user.TeamName, user.IsActive, user.DeletedAt, user.MaxOpenReviews, user.Seniority)
 	if err != nil {
 		return err
 	}
@@ -75,10 +98,10 @@ func (r *userRepository) Update(ctx context.Context, user *domain.User) error {
 
 func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 	const q = `
-	INSERT INTO users (user_id, username, team_name, is_active)
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO users (user_id, username, team_name, is_active, deleted_at, max_open_reviews, seniority)
+	VALUES ($1, $2, $3, $4, $5, $6, COALESCE(NULLIF($7, ''), 'middle'))
 `
-	_, err := r.db.ExecContext(ctx, q, user.ID, user.Username, user.TeamName, user.IsActive)
+	_, err := r.db.ExecContext(ctx, q, user.ID, user.Username, user.TeamName, user.IsActive, user.DeletedAt, user.MaxOpenReviews, user.Seniority)
 	if err != nil {
 		return err
 	}
@@ -87,10 +110,11 @@ func (r *userRepository) Create(ctx context.Context, user *domain.User) error {
 }
 
 func (r *userRepository) ListActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
-	const q = `
-	SELECT user_id, username, team_name, is_active
+	q := `
+	SELECT ` + userColumns + `
 	FROM users
-	WHERE team_name = $1
+	WHERE team_name = $1 AND deleted_at IS NULL
+	ORDER BY user_id
 	`
 
 	rows, err := r.db.QueryContext(ctx, q, teamName)
@@ -99,7 +123,7 @@ func (r *userRepository) ListActiveByTeam(ctx context.Context, teamName string)
 	}
 	defer func() {
 		if err := rows.Close(); err != nil {
-			log.Println("failed to close rows:", err)
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
 		}
 	}()
 
@@ -107,7 +131,7 @@ func (r *userRepository) ListActiveByTeam(ctx context.Context, teamName string)
 
 	for rows.Next() {
 		item := &domain.User{}
-		if err := rows.Scan(&item.ID, &item.Username, &item.TeamName, &item.IsActive); err != nil {
+		if err := scanUser(rows, item); err != nil {
 			return nil, err
 		}
 		result = append(result, item)
@@ -124,3 +148,240 @@ func (r *userRepository) ListActiveByTeam(ctx context.Context, teamName string)
 
 	return result, nil
 }
+
+func (r *userRepository) ListByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	q := `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE user_id = ANY($1)
+	ORDER BY user_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := make([]*domain.User, 0, len(ids))
+
+	for rows.Next() {
+		item := &domain.User{}
+		if err := scanUser(rows, item); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ListAfter returns up to limit users with user_id greater than afterID, ordered by user_id.
+func (r *userRepository) ListAfter(ctx context.Context, afterID string, limit int) ([]*domain.User, error) {
+	q := `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE user_id > $1
+	ORDER BY user_id
+	LIMIT $2
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := make([]*domain.User, 0, limit)
+
+	for rows.Next() {
+		item := &domain.User{}
+		if err := scanUser(rows, item); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// likeEscaper escapes LIKE wildcards so a search query matches literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// List returns up to filter.Limit users matching filter in keyset order. Offboarded users are omitted.
+func (r *userRepository) List(ctx context.Context, filter domain.UserListFilter) ([]domain.User, error) {
+	var (
+		conds = []string{"deleted_at IS NULL"}
+		args  []any
+	)
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if filter.TeamName != "" {
+		conds = append(conds, "team_name = "+arg(filter.TeamName))
+	}
+	if filter.IsActive != nil {
+		conds = append(conds, "is_active = "+arg(*filter.IsActive))
+	}
+	if filter.Query != "" {
+		conds = append(conds, "username ILIKE "+arg("%"+likeEscaper.Replace(filter.Query)+"%"))
+	}
+	if filter.After != nil {
+		conds = append(conds, fmt.Sprintf("(username, user_id) > (%s, %s)", arg(filter.After.Username), arg(filter.After.ID)))
+	}
+
+	q := `
+	SELECT ` + userColumns + `
+	FROM users
+	WHERE ` + strings.Join(conds, " AND ") + `
+	ORDER BY username, user_id
+	LIMIT ` + arg(filter.Limit) + `
+	`
+
+	rows, err := r.db.QueryContext(ctx, q, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := make([]domain.User, 0, filter.Limit)
+
+	for rows.Next() {
+		var item domain.User
+		if err := scanUser(rows, &item); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *userRepository) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	const q = `
+	SELECT name, description
+	FROM tags
+	ORDER BY name
+	`
+
+	rows, err := r.db.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	result := []domain.Tag{}
+
+	for rows.Next() {
+		var t domain.Tag
+		if err := rows.Scan(&t.Name, &t.Description); err != nil {
+			return nil, err
+		}
+		result = append(result, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *userRepository) CreateTag(ctx context.Context, tag *domain.Tag) error {
+	const q = `
+	INSERT INTO tags (name, description, created_at)
+	VALUES ($1, $2, now())
+	`
+
+	_, err := r.db.ExecContext(ctx, q, tag.Name, tag.Description)
+	return err
+}
+
+func (r *userRepository) SetTags(ctx context.Context, userID string, tags []string) error {
+	const del = `
+	DELETE FROM user_tags
+	WHERE user_id = $1
+	`
+	const ins = `
+	INSERT INTO user_tags (user_id, tag)
+	SELECT $1, unnest($2::TEXT[])
+	`
+
+	if _, err := r.db.ExecContext(ctx, del, userID); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	_, err := r.db.ExecContext(ctx, ins, userID, pq.Array(tags))
+	return err
+}
+
+func (r *userRepository) TagsByUsers(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	const q = `
+	SELECT user_id, tag
+	FROM user_tags
+	WHERE user_id = ANY($1)
+	ORDER BY user_id, tag
+	`
+
+	result := make(map[string][]string)
+	if len(userIDs) == 0 {
+		return result, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, q, pq.Array(userIDs))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := rows.Close(); err != nil {
+			logger.FromContext(ctx).Warn("failed to close rows", zap.Error(err))
+		}
+	}()
+
+	for rows.Next() {
+		var id, tag string
+		if err := rows.Scan(&id, &tag); err != nil {
+			return nil, err
+		}
+		result[id] = append(result[id], tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,765 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/archive"
+	"github.com/CodebyTecs/pr-assign-service/internal/codeowners"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// exportBatchSize is the number of rows read per query while exporting.
+const exportBatchSize = 500
+
+type ImportArchiveInput struct {
+	Body io.Reader
+	Mode domain.RestoreMode
+}
+
+type AdminService interface {
+	Export(ctx context.Context, w io.Writer) error
+	Import(ctx context.Context, input ImportArchiveInput) (*domain.ArchiveImportResult, error)
+}
+
+type adminService struct {
+	userRepo           repository.UserRepository
+	teamRepo           repository.TeamRepository
+	prRepo             repository.PRRepository
+	codeownersRepo     repository.CodeownersRepository
+	reviewRulesRepo    repository.ReviewRulesRepository
+	unavailabilityRepo repository.UnavailabilityRepository
+	tx                 repository.Transactor
+}
+
+func NewAdminService(userRepo repository.UserRepository, teamRepo repository.TeamRepository, prRepo repository.PRRepository, codeownersRepo repository.CodeownersRepository, reviewRulesRepo repository.ReviewRulesRepository, unavailabilityRepo repository.UnavailabilityRepository, tx repository.Transactor) AdminService {
+	return &adminService{
+		userRepo:           userRepo,
+		teamRepo:           teamRepo,
+		prRepo:             prRepo,
+		codeownersRepo:     codeownersRepo,
+		reviewRulesRepo:    reviewRulesRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		tx:                 tx,
+	}
+}
+
+// Export writes the tag catalog, every team with its CODEOWNERS file and
+// review rules, every user with their tags and unavailability windows, and
+// every pull request to w. Nothing is written when the caller is not allowed
+// to export.
+func (s *adminService) Export(ctx context.Context, w io.Writer) error {
+	ctx, span := tracer.Start(ctx, "AdminService.Export")
+	defer span.End()
+
+	if err := authorize(ctx, actionManageData, ""); err != nil {
+		return err
+	}
+
+	// One snapshot keeps the archive consistent: a pull request created while
+	// exporting must not reference a user the export has already passed.
+	return s.tx.WithinSnapshot(ctx, func(ctx context.Context) error {
+		return s.export(ctx, w)
+	})
+}
+
+func (s *adminService) export(ctx context.Context, w io.Writer) error {
+	enc := archive.NewEncoder(w)
+	if err := enc.WriteHeader(time.Now().UTC()); err != nil {
+		return err
+	}
+
+	tags, err := s.userRepo.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+	for i := range tags {
+		if err := enc.WriteTag(&tags[i]); err != nil {
+			return err
+		}
+	}
+
+	teams, err := s.teamRepo.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range teams {
+		if err := enc.WriteTeam(t); err != nil {
+			return err
+		}
+	}
+
+	if err := s.exportUsers(ctx, enc); err != nil {
+		return err
+	}
+	if err := s.exportTeamSettings(ctx, enc, teams); err != nil {
+		return err
+	}
+
+	for after := int64(0); ; {
+		windows, err := s.unavailabilityRepo.ListAfter(ctx, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for i := range windows {
+			if err := enc.WriteUnavailability(&windows[i]); err != nil {
+				return err
+			}
+		}
+		if len(windows) < exportBatchSize {
+			break
+		}
+		after = windows[len(windows)-1].ID
+	}
+
+	for after := ""; ; {
+		prs, err := s.prRepo.ListAfter(ctx, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+		for _, pr := range prs {
+			if err := enc.WritePullRequest(pr); err != nil {
+				return err
+			}
+		}
+		if len(prs) < exportBatchSize {
+			break
+		}
+		after = prs[len(prs)-1].ID
+	}
+
+	return nil
+}
+
+// exportUsers writes every user, each batch followed by the tags of its users.
+func (s *adminService) exportUsers(ctx context.Context, enc *archive.Encoder) error {
+	for after := ""; ; {
+		users, err := s.userRepo.ListAfter(ctx, after, exportBatchSize)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, 0, len(users))
+		for _, u := range users {
+			if err := enc.WriteUser(u); err != nil {
+				return err
+			}
+			ids = append(ids, u.ID)
+		}
+
+		tags, err := s.userRepo.TagsByUsers(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, id := range ids {
+			if len(tags[id]) == 0 {
+				continue
+			}
+			if err := enc.WriteUserTags(&domain.UserTags{UserID: id, Tags: tags[id]}); err != nil {
+				return err
+			}
+		}
+
+		if len(users) < exportBatchSize {
+			return nil
+		}
+		after = users[len(users)-1].ID
+	}
+}
+
+// exportTeamSettings writes the CODEOWNERS file and the review rules of each
+// of teams that has them.
+func (s *adminService) exportTeamSettings(ctx context.Context, enc *archive.Encoder, teams []*domain.Team) error {
+	for _, t := range teams {
+		file, err := s.codeownersRepo.Get(ctx, t.Name)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if file != nil {
+			if err := enc.WriteCodeowners(file); err != nil {
+				return err
+			}
+		}
+
+		rules, err := s.reviewRulesRepo.Get(ctx, t.Name)
+		if err != nil {
+			return err
+		}
+		if !noReviewRules(rules) {
+			if err := enc.WriteReviewRules(rules); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Import validates the whole archive and then applies it in one transaction.
+// In merge mode records that already exist unchanged are skipped and records
+// that differ are reported as conflicts and left untouched.
+func (s *adminService) Import(ctx context.Context, input ImportArchiveInput) (*domain.ArchiveImportResult, error) {
+	ctx, span := tracer.Start(ctx, "AdminService.Import")
+	defer span.End()
+
+	if err := authorize(ctx, actionManageData, ""); err != nil {
+		return nil, err
+	}
+
+	result := &domain.ArchiveImportResult{Mode: input.Mode}
+
+	a, rowErrs, err := archive.Read(input.Body)
+	if err != nil {
+		result.Errors = []domain.ImportRowError{{Message: err.Error()}}
+		return result, domain.ErrInvalidImport
+	}
+	result.Errors = rowErrs
+
+	if input.Mode == domain.RestoreModeRestore {
+		teams, err := s.teamRepo.List(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(teams) > 0 {
+			return nil, domain.ErrNotEmpty
+		}
+	}
+
+	if err := s.validateArchive(ctx, a, result); err != nil {
+		return nil, err
+	}
+	if len(result.Errors) > 0 {
+		return result, domain.ErrInvalidImport
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.applyArchive(ctx, a, result)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("archive imported",
+		zap.String("mode", string(result.Mode)),
+		zap.Int("teams", result.Teams.Created),
+		zap.Int("users", result.Users.Created),
+		zap.Int("pull_requests", result.PullRequests.Created),
+		zap.Int("tags", result.Tags.Created),
+		zap.Int("user_tags", result.UserTags.Created),
+		zap.Int("codeowners", result.Codeowners.Created),
+		zap.Int("review_rules", result.ReviewRules.Created),
+		zap.Int("unavailability", result.Unavailability.Created),
+		zap.Int("conflicts", len(result.Conflicts)),
+	)
+
+	return result, nil
+}
+
+// validateArchive checks required fields, duplicates and that every team,
+// user and tag a record refers to exists in the archive or the database.
+func (s *adminService) validateArchive(ctx context.Context, a *archive.Archive, result *domain.ArchiveImportResult) error {
+	rowErr := func(line int, field, msg string) {
+		result.Errors = append(result.Errors, domain.ImportRowError{Row: line, Field: field, Message: msg})
+	}
+
+	tags := make(map[string]bool, len(a.Tags))
+	for _, t := range a.Tags {
+		switch {
+		case !domain.ValidTagName(t.Value.Name):
+			rowErr(t.Line, "name", "invalid tag name")
+		case tags[t.Value.Name]:
+			rowErr(t.Line, "name", "tag is listed more than once")
+		}
+		tags[t.Value.Name] = true
+	}
+
+	teams := make(map[string]bool, len(a.Teams))
+	for _, t := range a.Teams {
+		switch {
+		case t.Value.Name == "":
+			rowErr(t.Line, "team_name", "is required")
+		case teams[t.Value.Name]:
+			rowErr(t.Line, "team_name", "team is listed more than once")
+		case t.Value.CapacityPolicy != "" && !t.Value.CapacityPolicy.Valid():
+			rowErr(t.Line, "capacity_policy", "must be reject or least_loaded")
+		}
+		teams[t.Value.Name] = true
+	}
+
+	for _, t := range a.Teams {
+		for _, partner := range t.Value.PartnerTeams {
+			if partner == t.Value.Name {
+				rowErr(t.Line, "partner_teams", "team cannot be its own partner")
+				continue
+			}
+			if teams[partner] {
+				continue
+			}
+			ok, err := s.teamExists(ctx, partner)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				rowErr(t.Line, "partner_teams", fmt.Sprintf("unknown team %q", partner))
+			}
+		}
+	}
+
+	// teamKnown and userKnown look up references missing from the archive in
+	// the database, remembering the answer.
+	teamKnown := func(name string) (bool, error) {
+		if ok, seen := teams[name]; seen {
+			return ok, nil
+		}
+		ok, err := s.teamExists(ctx, name)
+		teams[name] = ok
+		return ok, err
+	}
+	users := make(map[string]bool, len(a.Users))
+	userKnown := func(id string) (bool, error) {
+		if ok, seen := users[id]; seen {
+			return ok, nil
+		}
+		_, err := s.userRepo.GetByID(ctx, id)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return false, err
+		}
+		users[id] = err == nil
+		return err == nil, nil
+	}
+
+	for _, u := range a.Users {
+		switch {
+		case u.Value.ID == "":
+			rowErr(u.Line, "user_id", "is required")
+			continue
+		case u.Value.Username == "":
+			rowErr(u.Line, "username", "is required")
+		case users[u.Value.ID]:
+			rowErr(u.Line, "user_id", "user is listed more than once")
+		case u.Value.MaxOpenReviews != nil && *u.Value.MaxOpenReviews <= 0:
+			rowErr(u.Line, "max_open_reviews", "must be positive")
+		case u.Value.Seniority != "" && !u.Value.Seniority.Valid():
+			rowErr(u.Line, "seniority", "must be junior, middle or senior")
+		}
+		users[u.Value.ID] = true
+
+		ok, err := teamKnown(u.Value.TeamName)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			rowErr(u.Line, "team_name", "team not found")
+		}
+	}
+
+	if err := s.validateUserRecords(ctx, a, tags, userKnown, rowErr); err != nil {
+		return err
+	}
+	if err := s.validateTeamSettings(a, teamKnown, userKnown, rowErr); err != nil {
+		return err
+	}
+
+	prs := make(map[string]bool, len(a.PullRequests))
+	for _, p := range a.PullRequests {
+		pr := p.Value
+		switch {
+		case pr.ID == "":
+			rowErr(p.Line, "pull_request_id", "is required")
+			continue
+		case pr.Name == "":
+			rowErr(p.Line, "pull_request_name", "is required")
+		case pr.Status != domain.PRStatusOpen && pr.Status != domain.PRStatusMerged:
+			rowErr(p.Line, "status", "must be OPEN or MERGED")
+		case pr.CreatedAt == nil:
+			rowErr(p.Line, "createdAt", "is required")
+		case prs[pr.ID]:
+			rowErr(p.Line, "pull_request_id", "pull request is listed more than once")
+		}
+		prs[pr.ID] = true
+
+		ok, err := userKnown(pr.AuthorID)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			rowErr(p.Line, "author_id", "author not found")
+		}
+	}
+
+	return nil
+}
+
+// validateUserRecords checks the tags and unavailability windows of users.
+// tags holds the tags of the archive.
+func (s *adminService) validateUserRecords(ctx context.Context, a *archive.Archive, tags map[string]bool, userKnown func(string) (bool, error), rowErr func(int, string, string)) error {
+	var catalog []domain.Tag
+	if len(a.UserTags) > 0 {
+		var err error
+		if catalog, err = s.userRepo.ListTags(ctx); err != nil {
+			return err
+		}
+	}
+
+	seen := make(map[string]bool, len(a.UserTags))
+	for _, t := range a.UserTags {
+		ok, err := userKnown(t.Value.UserID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !ok:
+			rowErr(t.Line, "user_id", "user not found")
+		case seen[t.Value.UserID]:
+			rowErr(t.Line, "user_id", "user tags are listed more than once")
+		}
+		seen[t.Value.UserID] = true
+
+		for _, tag := range t.Value.Tags {
+			if !tags[tag] && !slices.ContainsFunc(catalog, func(c domain.Tag) bool { return c.Name == tag }) {
+				rowErr(t.Line, "tags", fmt.Sprintf("unknown tag %q", tag))
+			}
+		}
+	}
+
+	for _, u := range a.Unavailability {
+		ok, err := userKnown(u.Value.UserID)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !ok:
+			rowErr(u.Line, "user_id", "user not found")
+		case !u.Value.EndsAt.After(u.Value.StartsAt):
+			rowErr(u.Line, "ends_at", "must be after starts_at")
+		}
+	}
+
+	return nil
+}
+
+// validateTeamSettings checks that CODEOWNERS files parse and that review
+// rules refer to known users. Owners and rule constraints were checked when
+// the settings were saved and are not checked again.
+func (s *adminService) validateTeamSettings(a *archive.Archive, teamKnown, userKnown func(string) (bool, error), rowErr func(int, string, string)) error {
+	seen := make(map[string]bool, len(a.Codeowners))
+	for _, c := range a.Codeowners {
+		ok, err := teamKnown(c.Value.TeamName)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !ok:
+			rowErr(c.Line, "team_name", "team not found")
+		case seen[c.Value.TeamName]:
+			rowErr(c.Line, "team_name", "codeowners file is listed more than once")
+		}
+		seen[c.Value.TeamName] = true
+
+		_, lineErrs, err := codeowners.Parse(strings.NewReader(c.Value.Content))
+		if err == nil && len(lineErrs) > 0 {
+			err = fmt.Errorf("line %d: %s", lineErrs[0].Row, lineErrs[0].Message)
+		}
+		if err != nil {
+			rowErr(c.Line, "content", err.Error())
+		}
+	}
+
+	seen = make(map[string]bool, len(a.ReviewRules))
+	for _, r := range a.ReviewRules {
+		ok, err := teamKnown(r.Value.TeamName)
+		if err != nil {
+			return err
+		}
+		switch {
+		case !ok:
+			rowErr(r.Line, "team_name", "team not found")
+		case seen[r.Value.TeamName]:
+			rowErr(r.Line, "team_name", "review rules are listed more than once")
+		}
+		seen[r.Value.TeamName] = true
+
+		var ids []string
+		for _, m := range r.Value.AlwaysInclude {
+			ids = append(ids, m.UserID)
+		}
+		for _, p := range r.Value.NeverPair {
+			ids = append(ids, p.AuthorID, p.ReviewerID)
+		}
+		for _, id := range ids {
+			ok, err := userKnown(id)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				rowErr(r.Line, "review_rules", fmt.Sprintf("unknown user %q", id))
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *adminService) applyArchive(ctx context.Context, a *archive.Archive, result *domain.ArchiveImportResult) error {
+	conflict := func(line int, typ, id, msg string) {
+		result.Conflicts = append(result.Conflicts, domain.ArchiveConflict{Line: line, Type: typ, ID: id, Message: msg})
+	}
+
+	catalog, err := s.userRepo.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range a.Tags {
+		i := slices.IndexFunc(catalog, func(c domain.Tag) bool { return c.Name == t.Value.Name })
+		if i >= 0 {
+			if catalog[i].Description != t.Value.Description {
+				conflict(t.Line, string(archive.RecordTag), t.Value.Name, "tag differs from the stored one")
+				continue
+			}
+			result.Tags.Skipped++
+			continue
+		}
+		tag := t.Value
+		if err := s.userRepo.CreateTag(ctx, &tag); err != nil {
+			return err
+		}
+		result.Tags.Created++
+	}
+
+	for _, t := range a.Teams {
+		ok, err := s.teamExists(ctx, t.Value.Name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			result.Teams.Skipped++
+			continue
+		}
+		team := &domain.Team{Name: t.Value.Name, CapacityPolicy: t.Value.CapacityPolicy, PartnerTeams: t.Value.PartnerTeams, Mentoring: t.Value.Mentoring}
+		if err := s.teamRepo.Create(ctx, team); err != nil {
+			return err
+		}
+		result.Teams.Created++
+	}
+
+	for _, u := range a.Users {
+		user := u.Value
+		existing, err := s.userRepo.GetByID(ctx, user.ID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			if !sameUser(existing, &user) {
+				conflict(u.Line, string(archive.RecordUser), user.ID, "user differs from the stored one")
+				continue
+			}
+			result.Users.Skipped++
+			continue
+		}
+		if err := s.userRepo.Create(ctx, &user); err != nil {
+			return err
+		}
+		result.Users.Created++
+	}
+
+	if err := s.applyUserRecords(ctx, a, conflict, result); err != nil {
+		return err
+	}
+	if err := s.applyTeamSettings(ctx, a, conflict, result); err != nil {
+		return err
+	}
+
+	for _, p := range a.PullRequests {
+		pr := p.Value
+		existing, err := s.prRepo.GetByID(ctx, pr.ID)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			if !samePullRequest(existing, &pr) {
+				conflict(p.Line, string(archive.RecordPullRequest), pr.ID, "pull request differs from the stored one")
+				continue
+			}
+			result.PullRequests.Skipped++
+			continue
+		}
+		if pr.Reviewers == nil {
+			pr.Reviewers = []string{}
+		}
+		if err := s.prRepo.Create(ctx, &pr); err != nil {
+			return fmt.Errorf("can't restore pull request %s: %w", pr.ID, err)
+		}
+		result.PullRequests.Created++
+	}
+
+	return nil
+}
+
+// applyUserRecords imports user tags and unavailability windows. Tags are
+// only set for users without any; a window is matched by its user and period.
+func (s *adminService) applyUserRecords(ctx context.Context, a *archive.Archive, conflict func(int, string, string, string), result *domain.ArchiveImportResult) error {
+	for _, t := range a.UserTags {
+		stored, err := s.userRepo.TagsByUsers(ctx, []string{t.Value.UserID})
+		if err != nil {
+			return err
+		}
+		tags := slices.Sorted(slices.Values(t.Value.Tags))
+		switch existing := stored[t.Value.UserID]; {
+		case len(existing) == 0:
+			if err := s.userRepo.SetTags(ctx, t.Value.UserID, tags); err != nil {
+				return err
+			}
+			result.UserTags.Created++
+		case slices.Equal(existing, tags):
+			result.UserTags.Skipped++
+		default:
+			conflict(t.Line, string(archive.RecordUserTags), t.Value.UserID, "user tags differ from the stored ones")
+		}
+	}
+
+	for _, u := range a.Unavailability {
+		window := u.Value
+		stored, err := s.unavailabilityRepo.ListByUser(ctx, window.UserID, time.Time{})
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(stored, func(w domain.Unavailability) bool {
+			return w.StartsAt.Equal(window.StartsAt) && w.EndsAt.Equal(window.EndsAt)
+		})
+		if i >= 0 {
+			if stored[i].Reason != window.Reason {
+				conflict(u.Line, string(archive.RecordUnavailability), window.UserID, "unavailability window differs from the stored one")
+				continue
+			}
+			result.Unavailability.Skipped++
+			continue
+		}
+		if window.CreatedAt.IsZero() {
+			window.CreatedAt = time.Now()
+		}
+		if err := s.unavailabilityRepo.Create(ctx, &window); err != nil {
+			return err
+		}
+		result.Unavailability.Created++
+	}
+
+	return nil
+}
+
+// applyTeamSettings imports CODEOWNERS files and review rules of teams that
+// have none.
+func (s *adminService) applyTeamSettings(ctx context.Context, a *archive.Archive, conflict func(int, string, string, string), result *domain.ArchiveImportResult) error {
+	for _, c := range a.Codeowners {
+		file := c.Value
+		existing, err := s.codeownersRepo.Get(ctx, file.TeamName)
+		if err != nil && !errors.Is(err, repository.ErrNotFound) {
+			return err
+		}
+		if existing != nil {
+			if existing.Content != file.Content {
+				conflict(c.Line, string(archive.RecordCodeowners), file.TeamName, "codeowners file differs from the stored one")
+				continue
+			}
+			result.Codeowners.Skipped++
+			continue
+		}
+		if file.UpdatedAt.IsZero() {
+			file.UpdatedAt = time.Now()
+		}
+		if err := s.codeownersRepo.Save(ctx, &file); err != nil {
+			return err
+		}
+		result.Codeowners.Created++
+	}
+
+	for _, r := range a.ReviewRules {
+		rules := r.Value
+		existing, err := s.reviewRulesRepo.Get(ctx, rules.TeamName)
+		if err != nil {
+			return err
+		}
+		if !noReviewRules(existing) {
+			if !sameReviewRules(existing, &rules) {
+				conflict(r.Line, string(archive.RecordReviewRules), rules.TeamName, "review rules differ from the stored ones")
+				continue
+			}
+			result.ReviewRules.Skipped++
+			continue
+		}
+		if err := s.reviewRulesRepo.Save(ctx, &rules); err != nil {
+			return err
+		}
+		result.ReviewRules.Created++
+	}
+
+	return nil
+}
+
+func (s *adminService) teamExists(ctx context.Context, name string) (bool, error) {
+	_, err := s.teamRepo.GetByName(ctx, name)
+	if err == nil {
+		return true, nil
+	}
+	if errors.Is(err, repository.ErrNotFound) {
+		return false, nil
+	}
+	return false, err
+}
+
+// sameUser compares a stored user with an archived one. Archives written
+// before seniority levels existed carry no seniority, which matches any.
+func sameUser(a, b *domain.User) bool {
+	return a.Username == b.Username &&
+		a.TeamName == b.TeamName &&
+		a.IsActive == b.IsActive &&
+		sameTime(a.DeletedAt, b.DeletedAt) &&
+		sameLimit(a.MaxOpenReviews, b.MaxOpenReviews) &&
+		(b.Seniority == "" || a.Seniority == b.Seniority)
+}
+
+func samePullRequest(a, b *domain.PullRequest) bool {
+	return a.Name == b.Name &&
+		a.AuthorID == b.AuthorID &&
+		a.Status == b.Status &&
+		a.MergedBy == b.MergedBy &&
+		slices.Equal(a.Reviewers, b.Reviewers) &&
+		slices.Equal(a.RequiredTags, b.RequiredTags) &&
+		slices.Equal(a.ShadowReviewers, b.ShadowReviewers) &&
+		sameTime(a.CreatedAt, b.CreatedAt) &&
+		sameTime(a.MergedAt, b.MergedAt)
+}
+
+func noReviewRules(r *domain.ReviewRules) bool {
+	return len(r.AlwaysInclude) == 0 && len(r.NeverPair) == 0 && !r.RequireSenior
+}
+
+func sameReviewRules(a, b *domain.ReviewRules) bool {
+	return a.RequireSenior == b.RequireSenior &&
+		slices.Equal(a.NeverPair, b.NeverPair) &&
+		slices.EqualFunc(a.AlwaysInclude, b.AlwaysInclude, func(x, y domain.MandatoryReviewer) bool {
+			return x.UserID == y.UserID && slices.Equal(x.Paths, y.Paths)
+		})
+}
+
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+func sameLimit(a, b *int) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/archive"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// snapshotReads records the transaction kind of every read made by Export.
+type snapshotReads struct {
+	kinds []string
+}
+
+type fakeExportTeamRepo struct {
+	repository.TeamRepository
+	reads *snapshotReads
+}
+
+func (f *fakeExportTeamRepo) List(ctx context.Context) ([]*domain.Team, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return []*domain.Team{{Name: "backend"}}, nil
+}
+
+type fakeExportUserRepo struct {
+	repository.UserRepository
+	reads *snapshotReads
+}
+
+func (f *fakeExportUserRepo) ListAfter(ctx context.Context, afterID string, limit int) ([]*domain.User, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return []*domain.User{{ID: "u1", Username: "Alice", TeamName: "backend", IsActive: true}}, nil
+}
+
+func (f *fakeExportUserRepo) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return []domain.Tag{{Name: "go"}}, nil
+}
+
+func (f *fakeExportUserRepo) TagsByUsers(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return map[string][]string{"u1": {"go"}}, nil
+}
+
+type fakeExportCodeownersRepo struct {
+	repository.CodeownersRepository
+	reads *snapshotReads
+}
+
+func (f *fakeExportCodeownersRepo) Get(ctx context.Context, teamName string) (*domain.Codeowners, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return &domain.Codeowners{TeamName: teamName, Content: "* @u1\n"}, nil
+}
+
+type fakeExportReviewRulesRepo struct {
+	repository.ReviewRulesRepository
+	reads *snapshotReads
+}
+
+func (f *fakeExportReviewRulesRepo) Get(ctx context.Context, teamName string) (*domain.ReviewRules, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return &domain.ReviewRules{TeamName: teamName, RequireSenior: true}, nil
+}
+
+type fakeExportUnavailabilityRepo struct {
+	repository.UnavailabilityRepository
+	reads *snapshotReads
+}
+
+func (f *fakeExportUnavailabilityRepo) ListAfter(ctx context.Context, afterID int64, limit int) ([]domain.Unavailability, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return []domain.Unavailability{{ID: 1, UserID: "u1"}}, nil
+}
+
+type fakeExportPRRepo struct {
+	repository.PRRepository
+	reads *snapshotReads
+}
+
+func (f *fakeExportPRRepo) ListAfter(ctx context.Context, afterID string, limit int) ([]*domain.PullRequest, error) {
+	f.reads.kinds = append(f.reads.kinds, fakeTxKind(ctx))
+	return []*domain.PullRequest{{ID: "pr-1", Name: "a", AuthorID: "u1", Status: domain.PRStatusOpen}}, nil
+}
+
+func TestExportReadsOneSnapshot(t *testing.T) {
+	reads := &snapshotReads{}
+	svc := NewAdminService(
+		&fakeExportUserRepo{reads: reads},
+		&fakeExportTeamRepo{reads: reads},
+		&fakeExportPRRepo{reads: reads},
+		&fakeExportCodeownersRepo{reads: reads},
+		&fakeExportReviewRulesRepo{reads: reads},
+		&fakeExportUnavailabilityRepo{reads: reads},
+		fakeTx{},
+	)
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	var buf bytes.Buffer
+	require.NoError(t, svc.Export(ctx, &buf))
+
+	assert.Len(t, reads.kinds, 8)
+	for _, kind := range reads.kinds {
+		assert.Equal(t, "snapshot", kind)
+	}
+
+	a, rowErrs, err := archive.Read(&buf)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrs)
+	assert.Len(t, a.Tags, 1)
+	assert.Len(t, a.UserTags, 1)
+	assert.Len(t, a.Codeowners, 1)
+	assert.Len(t, a.ReviewRules, 1)
+	assert.Len(t, a.Unavailability, 1)
+	assert.Len(t, a.PullRequests, 1)
+}
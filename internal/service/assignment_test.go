@@ -0,0 +1,263 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+// assignmentSeeds are the fixed seeds every assignment test is run with.
+var assignmentSeeds = []int64{1, 7, 42, 1000}
+
+type assignmentWorld struct {
+	users []*domain.User
+	tags  map[string][]string
+	teams map[string]*domain.Team
+	rules *domain.ReviewRules
+}
+
+func member(id, team string, seniority domain.Seniority) *domain.User {
+	return &domain.User{ID: id, TeamName: team, IsActive: true, Seniority: seniority}
+}
+
+func (w assignmentWorld) service() *prService {
+	rules := w.rules
+	if rules == nil {
+		rules = &domain.ReviewRules{}
+	}
+	return NewPRService(
+		&fakeWritePRRepo{PRRepository: &fakePRRepo{}},
+		&fakeUserRepo{users: w.users, tags: w.tags},
+		&fakeTeamRepo{teams: w.teams},
+		&fakeUnavailabilityRepo{},
+		nil,
+		&fakeReviewRulesRepo{rules: rules},
+		fakeTx{},
+	).(*prService)
+}
+
+// propose runs the reviewer selection for a pull request of authorID with seed.
+func (w assignmentWorld) propose(t *testing.T, seed int64, authorID string, tags ...string) []domain.AssignedReviewer {
+	t.Helper()
+
+	s := w.service()
+	author, err := s.userRepo.GetByID(context.Background(), authorID)
+	require.NoError(t, err)
+
+	ctx := withDecision(context.Background(), newDecisionRecorder(domain.DecisionKindCreate, seed))
+	_, assigned, err := s.propose(ctx, author, CreatePRInput{ID: "pr-1", Author: authorID, RequiredTags: tags}, time.Now())
+	require.NoError(t, err)
+	return assigned
+}
+
+func reviewerIDs(assigned []domain.AssignedReviewer) []string {
+	ids := make([]string, 0, len(assigned))
+	for _, a := range assigned {
+		ids = append(ids, a.UserID)
+	}
+	return ids
+}
+
+func TestProposeReplaysWithSameSeed(t *testing.T) {
+	w := assignmentWorld{users: []*domain.User{
+		member("author", "backend", domain.SeniorityMiddle),
+		member("u1", "backend", domain.SeniorityMiddle),
+		member("u2", "backend", domain.SeniorityMiddle),
+		member("u3", "backend", domain.SeniorityMiddle),
+		member("u4", "backend", domain.SeniorityMiddle),
+	}}
+
+	for _, seed := range assignmentSeeds {
+		assert.Equal(t, w.propose(t, seed, "author"), w.propose(t, seed, "author"), "seed %d", seed)
+	}
+}
+
+func TestProposeCoversRequiredTags(t *testing.T) {
+	w := assignmentWorld{
+		users: []*domain.User{
+			member("author", "backend", domain.SeniorityMiddle),
+			member("u1", "backend", domain.SeniorityMiddle),
+			member("u2", "backend", domain.SeniorityMiddle),
+			member("u3", "backend", domain.SeniorityMiddle),
+			member("u4", "backend", domain.SeniorityMiddle),
+			member("u5", "backend", domain.SeniorityMiddle),
+		},
+		tags: map[string][]string{
+			"u2": {"go"},
+			"u4": {"go", "security"},
+			"u5": {"postgres"},
+		},
+	}
+
+	for _, seed := range assignmentSeeds {
+		assigned := w.propose(t, seed, "author", "postgres", "security")
+
+		require.Len(t, assigned, 2, "seed %d", seed)
+		assert.ElementsMatch(t, []string{"u4", "u5"}, reviewerIDs(assigned), "seed %d", seed)
+		for _, a := range assigned {
+			assert.NotEmpty(t, a.CoveredTags, "seed %d", seed)
+		}
+	}
+}
+
+func TestProposeFallsBackToPartners(t *testing.T) {
+	w := assignmentWorld{
+		users: []*domain.User{
+			member("author", "backend", domain.SeniorityMiddle),
+			member("b1", "backend", domain.SeniorityMiddle),
+			member("f1", "frontend", domain.SeniorityMiddle),
+			member("p1", "platform", domain.SeniorityMiddle),
+			member("p2", "platform", domain.SeniorityMiddle),
+		},
+		teams: map[string]*domain.Team{
+			"backend": {Name: "backend", CapacityPolicy: domain.CapacityPolicyReject, PartnerTeams: []string{"platform"}},
+		},
+	}
+
+	for _, seed := range assignmentSeeds {
+		assigned := w.propose(t, seed, "author")
+
+		require.Len(t, assigned, 2, "seed %d", seed)
+		assert.Equal(t, domain.AssignedReviewer{UserID: "b1", Source: domain.ReviewerSourceTeam}, assigned[0])
+		assert.Contains(t, []string{"p1", "p2"}, assigned[1].UserID, "seed %d", seed)
+		assert.Equal(t, domain.ReviewerSourcePartner, assigned[1].Source)
+		assert.Equal(t, "platform", assigned[1].TeamName)
+	}
+}
+
+func TestProposeAppliesReviewRules(t *testing.T) {
+	w := assignmentWorld{
+		users: []*domain.User{
+			member("author", "backend", domain.SeniorityMiddle),
+			member("sec", "security", domain.SeniorityMiddle),
+			member("rival", "backend", domain.SenioritySenior),
+			member("s1", "backend", domain.SenioritySenior),
+			member("u1", "backend", domain.SeniorityMiddle),
+			member("u2", "backend", domain.SeniorityMiddle),
+			member("u3", "backend", domain.SeniorityMiddle),
+		},
+		rules: &domain.ReviewRules{
+			TeamName:      "backend",
+			AlwaysInclude: []domain.MandatoryReviewer{{UserID: "sec"}},
+			// Listed the other way round: the pair holds in both directions.
+			NeverPair:     []domain.NeverPair{{AuthorID: "rival", ReviewerID: "author"}},
+			RequireSenior: true,
+		},
+	}
+
+	for _, seed := range assignmentSeeds {
+		assigned := w.propose(t, seed, "author")
+
+		require.Len(t, assigned, 2, "seed %d", seed)
+		assert.Equal(t, domain.AssignedReviewer{UserID: "sec", Source: domain.ReviewerSourceMandatory}, assigned[0])
+		assert.Equal(t, "s1", assigned[1].UserID, "seed %d", seed)
+	}
+}
+
+func TestEnsureSeniorReplacesTeamReviewer(t *testing.T) {
+	w := assignmentWorld{users: []*domain.User{
+		member("author", "backend", domain.SeniorityMiddle),
+		member("sec", "security", domain.SeniorityMiddle),
+		member("s1", "backend", domain.SenioritySenior),
+		member("u1", "backend", domain.SeniorityMiddle),
+	}}
+	s := w.service()
+	assigned := []domain.AssignedReviewer{
+		{UserID: "sec", Source: domain.ReviewerSourceMandatory},
+		{UserID: "u1", Source: domain.ReviewerSourceTeam},
+	}
+
+	for _, seed := range assignmentSeeds {
+		ctx := withDecision(context.Background(), newDecisionRecorder(domain.DecisionKindCreate, seed))
+		result, err := s.ensureSenior(ctx, &domain.ReviewRules{TeamName: "backend", RequireSenior: true}, assigned, 1, time.Now(), "author")
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.AssignedReviewer{
+			{UserID: "sec", Source: domain.ReviewerSourceMandatory},
+			{UserID: "s1", Source: domain.ReviewerSourceSenior},
+		}, result)
+	}
+}
+
+func TestEnsureSeniorKeepsAssignedSenior(t *testing.T) {
+	w := assignmentWorld{users: []*domain.User{
+		member("author", "backend", domain.SeniorityMiddle),
+		member("s1", "backend", domain.SenioritySenior),
+		member("s2", "backend", domain.SenioritySenior),
+		member("u1", "backend", domain.SeniorityMiddle),
+	}}
+	s := w.service()
+	assigned := []domain.AssignedReviewer{
+		{UserID: "u1", Source: domain.ReviewerSourceTeam},
+		{UserID: "s2", Source: domain.ReviewerSourceTeam},
+	}
+
+	for _, seed := range assignmentSeeds {
+		ctx := withDecision(context.Background(), newDecisionRecorder(domain.DecisionKindCreate, seed))
+		result, err := s.ensureSenior(ctx, &domain.ReviewRules{TeamName: "backend", RequireSenior: true}, assigned, 0, time.Now(), "author")
+
+		require.NoError(t, err)
+		assert.Equal(t, assigned, result)
+	}
+}
+
+func TestAssignShadowPicksAvailableJunior(t *testing.T) {
+	w := assignmentWorld{
+		users: []*domain.User{
+			member("author", "backend", domain.SeniorityMiddle),
+			member("u1", "backend", domain.SeniorityMiddle),
+			member("u2", "backend", domain.SeniorityMiddle),
+			member("j1", "backend", domain.SeniorityJunior),
+			member("j2", "backend", domain.SeniorityJunior),
+			{ID: "j3", TeamName: "backend", IsActive: false, Seniority: domain.SeniorityJunior},
+			member("j4", "backend", domain.SeniorityJunior),
+		},
+		rules: &domain.ReviewRules{
+			TeamName:  "backend",
+			NeverPair: []domain.NeverPair{{AuthorID: "author", ReviewerID: "j2"}},
+		},
+	}
+	s := w.service()
+	author, err := s.userRepo.GetByID(context.Background(), "author")
+	require.NoError(t, err)
+
+	for _, seed := range assignmentSeeds {
+		ctx := withDecision(context.Background(), newDecisionRecorder(domain.DecisionKindCreate, seed))
+		// j4 already reviews, j2 is never paired with the author and j3 is inactive.
+		shadow, err := s.assignShadow(ctx, author, []string{"u1", "j4"}, time.Now())
+
+		require.NoError(t, err)
+		assert.Equal(t, &domain.AssignedReviewer{UserID: "j1", Source: domain.ReviewerSourceTeam, Role: domain.ReviewerRoleShadow}, shadow)
+	}
+}
+
+func TestProposeWithMentoringAddsShadow(t *testing.T) {
+	w := assignmentWorld{
+		users: []*domain.User{
+			member("author", "backend", domain.SeniorityMiddle),
+			member("u1", "backend", domain.SeniorityMiddle),
+			member("u2", "backend", domain.SeniorityMiddle),
+			member("j1", "backend", domain.SeniorityJunior),
+			member("j2", "backend", domain.SeniorityJunior),
+			member("j3", "backend", domain.SeniorityJunior),
+		},
+		teams: map[string]*domain.Team{
+			"backend": {Name: "backend", CapacityPolicy: domain.CapacityPolicyReject, Mentoring: true},
+		},
+	}
+
+	for _, seed := range assignmentSeeds {
+		assigned := w.propose(t, seed, "author")
+
+		require.Len(t, assigned, 3, "seed %d", seed)
+		shadow := assigned[2]
+		assert.Equal(t, domain.ReviewerRoleShadow, shadow.Role)
+		assert.Contains(t, []string{"j1", "j2", "j3"}, shadow.UserID)
+		assert.NotContains(t, reviewerIDs(assigned[:2]), shadow.UserID)
+	}
+}
@@ -0,0 +1,242 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+const tokenPrefix = "prs_"
+
+type IssueTokenInput struct {
+	Name     string
+	Role     domain.Role
+	TeamName string
+	// UserID ties the token to a person; offboarding the user revokes it.
+	UserID string
+}
+
+// IdentityVerifier validates identity provider tokens such as OIDC JWTs.
+type IdentityVerifier interface {
+	Verify(raw string) (*domain.ExternalIdentity, error)
+}
+
+type AuthService interface {
+	Authenticate(ctx context.Context, rawToken string) (*domain.Principal, error)
+	IssueToken(ctx context.Context, input IssueTokenInput) (string, *domain.APIToken, error)
+	RevokeToken(ctx context.Context, id string) error
+	EnsureBootstrapToken(ctx context.Context, rawToken string) error
+}
+
+type authService struct {
+	tokenRepo repository.TokenRepository
+	teamRepo  repository.TeamRepository
+	userRepo  repository.UserRepository
+	verifier  IdentityVerifier
+}
+
+// NewAuthService creates the auth service. verifier may be nil, in which case
+// only API tokens are accepted.
+func NewAuthService(tokenRepo repository.TokenRepository, teamRepo repository.TeamRepository, userRepo repository.UserRepository, verifier IdentityVerifier) AuthService {
+	return &authService{
+		tokenRepo: tokenRepo,
+		teamRepo:  teamRepo,
+		userRepo:  userRepo,
+		verifier:  verifier,
+	}
+}
+
+func (s *authService) Authenticate(ctx context.Context, rawToken string) (*domain.Principal, error) {
+	if rawToken == "" {
+		return nil, domain.ErrUnauthorized
+	}
+
+	if s.verifier != nil && looksLikeJWT(rawToken) {
+		return s.authenticateJWT(ctx, rawToken)
+	}
+
+	token, err := s.tokenRepo.GetByHash(ctx, hashToken(rawToken))
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+
+	if token.RevokedAt != nil {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return &domain.Principal{
+		Subject:  "token:" + token.ID,
+		UserID:   token.UserID,
+		Role:     token.Role,
+		TeamName: token.TeamName,
+	}, nil
+}
+
+func (s *authService) authenticateJWT(ctx context.Context, rawToken string) (*domain.Principal, error) {
+	identity, err := s.verifier.Verify(rawToken)
+	if err != nil {
+		logger.FromContext(ctx).Debug("jwt rejected", zap.Error(err))
+		return nil, domain.ErrUnauthorized
+	}
+
+	user, err := s.userRepo.GetByID(ctx, identity.UserID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrUnauthorized
+		}
+		return nil, err
+	}
+	if user.Deleted() {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return &domain.Principal{
+		Subject:  "user:" + user.ID,
+		UserID:   user.ID,
+		Role:     roleFromClaims(identity.Roles),
+		TeamName: user.TeamName,
+	}, nil
+}
+
+func (s *authService) IssueToken(ctx context.Context, input IssueTokenInput) (string, *domain.APIToken, error) {
+	ctx, span := tracer.Start(ctx, "AuthService.IssueToken")
+	defer span.End()
+
+	if err := authorize(ctx, actionManageTokens, ""); err != nil {
+		return "", nil, err
+	}
+
+	if input.TeamName != "" {
+		if _, err := s.teamRepo.GetByName(ctx, input.TeamName); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return "", nil, domain.ErrNotFound
+			}
+			return "", nil, err
+		}
+	}
+
+	if input.UserID != "" {
+		user, err := s.userRepo.GetByID(ctx, input.UserID)
+		if err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				return "", nil, domain.ErrNotFound
+			}
+			return "", nil, err
+		}
+		if user.Deleted() {
+			return "", nil, domain.ErrUserDeleted
+		}
+	}
+
+	raw, token := newAPIToken(input)
+	if err := s.tokenRepo.Create(ctx, token); err != nil {
+		return "", nil, err
+	}
+
+	logger.FromContext(ctx).Info("api token issued",
+		zap.String("token_id", token.ID),
+		zap.String("role", string(token.Role)),
+		zap.String("team_name", token.TeamName),
+	)
+
+	return raw, token, nil
+}
+
+func (s *authService) RevokeToken(ctx context.Context, id string) error {
+	ctx, span := tracer.Start(ctx, "AuthService.RevokeToken")
+	defer span.End()
+
+	if err := authorize(ctx, actionManageTokens, ""); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.Revoke(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	logger.FromContext(ctx).Info("api token revoked", zap.String("token_id", id))
+
+	return nil
+}
+
+// EnsureBootstrapToken stores rawToken as an admin token unless it already
+// exists, so a fresh deployment has a way to issue the first real tokens.
+func (s *authService) EnsureBootstrapToken(ctx context.Context, rawToken string) error {
+	hash := hashToken(rawToken)
+
+	_, err := s.tokenRepo.GetByHash(ctx, hash)
+	if err == nil {
+		return nil
+	} else if !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	token := &domain.APIToken{
+		ID:        "bootstrap-" + hash[:8],
+		Name:      "bootstrap admin",
+		Role:      domain.RoleAdmin,
+		Hash:      hash,
+		CreatedAt: time.Now(),
+	}
+
+	return s.tokenRepo.Create(ctx, token)
+}
+
+func newAPIToken(input IssueTokenInput) (string, *domain.APIToken) {
+	raw := tokenPrefix + randomHex(32)
+
+	return raw, &domain.APIToken{
+		ID:        randomHex(8),
+		Name:      input.Name,
+		Role:      input.Role,
+		TeamName:  input.TeamName,
+		UserID:    input.UserID,
+		Hash:      hashToken(raw),
+		CreatedAt: time.Now(),
+	}
+}
+
+// roleFromClaims maps identity provider roles to the most privileged service role.
+func roleFromClaims(roles []string) domain.Role {
+	role := domain.RoleMember
+	for _, r := range roles {
+		switch domain.Role(r) {
+		case domain.RoleAdmin:
+			return domain.RoleAdmin
+		case domain.RoleTeamLead:
+			role = domain.RoleTeamLead
+		}
+	}
+	return role
+}
+
+func looksLikeJWT(raw string) bool {
+	return strings.Count(raw, ".") == 2
+}
+
+func hashToken(raw string) string {
+	sum := sha256.Sum256([]byte(raw))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
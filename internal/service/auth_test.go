@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+type fakeVerifier struct {
+	identity *domain.ExternalIdentity
+}
+
+func (f fakeVerifier) Verify(raw string) (*domain.ExternalIdentity, error) {
+	return f.identity, nil
+}
+
+type fakeHashTokenRepo struct {
+	repository.TokenRepository
+	tokens map[string]*domain.APIToken
+}
+
+func (f *fakeHashTokenRepo) GetByHash(ctx context.Context, hash string) (*domain.APIToken, error) {
+	t, ok := f.tokens[hash]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return t, nil
+}
+
+func TestAuthenticateJWTRejectsOffboardedUser(t *testing.T) {
+	deletedAt := time.Now()
+	users := &fakeUserRepo{users: []*domain.User{
+		{ID: "lead", TeamName: "backend", IsActive: false, DeletedAt: &deletedAt},
+	}}
+	verifier := fakeVerifier{identity: &domain.ExternalIdentity{UserID: "lead", Roles: []string{"team_lead"}}}
+	svc := NewAuthService(nil, nil, users, verifier)
+
+	_, err := svc.Authenticate(context.Background(), "header.payload.signature")
+
+	assert.ErrorIs(t, err, domain.ErrUnauthorized)
+}
+
+func TestAuthenticateTokenCarriesUser(t *testing.T) {
+	tokens := &fakeHashTokenRepo{tokens: map[string]*domain.APIToken{
+		hashToken("prs_alice"): {ID: "t1", Role: domain.RoleTeamLead, TeamName: "backend", UserID: "alice"},
+	}}
+	svc := NewAuthService(tokens, nil, nil, nil)
+
+	p, err := svc.Authenticate(context.Background(), "prs_alice")
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", p.UserID)
+	assert.Equal(t, "token:t1", p.Subject)
+}
@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type principalKey struct{}
+
+func ContextWithPrincipal(ctx context.Context, p *domain.Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, p)
+}
+
+func PrincipalFromContext(ctx context.Context) (*domain.Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*domain.Principal)
+	return p, ok && p != nil
+}
+
+type action int
+
+const (
+	actionRead action = iota
+	actionManagePRs
+	actionManageUsers
+	actionManageTeams
+	actionConfigureTeam
+	actionManageTokens
+	actionManageData
+)
+
+// authorize checks whether the caller may perform act on resources owned by
+// teamName. Admins may do anything, every role may read, bots may manage pull
+// requests of any team, members may manage pull requests of their own team and
+// team leads may manage pull requests, users and settings of their own team only.
+func authorize(ctx context.Context, act action, teamName string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if p.Role == domain.RoleAdmin || act == actionRead {
+		return nil
+	}
+
+	switch act {
+	case actionManagePRs:
+		if p.Role == domain.RoleBot {
+			return nil
+		}
+		if (p.Role == domain.RoleTeamLead || p.Role == domain.RoleMember) && p.TeamName == teamName {
+			return nil
+		}
+	case actionManageUsers, actionConfigureTeam:
+		if p.Role == domain.RoleTeamLead && p.TeamName == teamName {
+			return nil
+		}
+	}
+
+	return domain.ErrForbidden
+}
+
+// authorizeUserLookup rejects callers who may not manage users of any team
+// before a user is looked up. Users are not secret, since any reader can list
+// them, so everyone else gets ErrNotFound for a missing user.
+func authorizeUserLookup(ctx context.Context) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	return authorize(ctx, actionManageUsers, p.TeamName)
+}
+
+// authorizeSelfLookup is authorizeUserLookup for settings users may manage
+// themselves, as authorizeSelf allows.
+func authorizeSelfLookup(ctx context.Context, userID string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if p.UserID != "" && p.UserID == userID {
+		return nil
+	}
+
+	return authorizeUserLookup(ctx)
+}
+
+// authorizeMerge allows only the pull request author or an admin to merge it.
+func authorizeMerge(ctx context.Context, pr *domain.PullRequest) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if p.Role == domain.RoleAdmin || (p.UserID != "" && p.UserID == pr.AuthorID) {
+		return nil
+	}
+
+	return domain.ErrForbidden
+}
+
+// authorizeDecline allows only the reviewer or an admin to decline a review.
+func authorizeDecline(ctx context.Context, reviewerID string) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if p.Role == domain.RoleAdmin || (p.UserID != "" && p.UserID == reviewerID) {
+		return nil
+	}
+
+	return domain.ErrForbidden
+}
+
+// authorizeSelf allows users to manage their own settings; anyone else needs
+// permission to manage users of the user's team.
+func authorizeSelf(ctx context.Context, user *domain.User) error {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return domain.ErrUnauthorized
+	}
+
+	if p.UserID != "" && p.UserID == user.ID {
+		return nil
+	}
+
+	return authorize(ctx, actionManageUsers, user.TeamName)
+}
+
+// actorID returns the audit identity of the caller, or an empty string when unknown.
+func actorID(ctx context.Context) string {
+	p, ok := PrincipalFromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return p.ActorID()
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/codeowners"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// resolveOwners maps CODEOWNERS owners to users: a user id stands for that
+// user and a team name for the members of the team. Owners that are neither
+// are returned as unknown.
+func resolveOwners(ctx context.Context, userRepo repository.UserRepository, teamRepo repository.TeamRepository, owners []string) (map[string][]*domain.User, []string, error) {
+	users, err := userRepo.ListByIDs(ctx, owners)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make(map[string][]*domain.User, len(owners))
+	for _, u := range users {
+		if !u.Deleted() {
+			result[u.ID] = []*domain.User{u}
+		}
+	}
+
+	var unknown []string
+	for _, o := range owners {
+		if _, ok := result[o]; ok {
+			continue
+		}
+
+		_, err := teamRepo.GetByName(ctx, o)
+		if errors.Is(err, repository.ErrNotFound) {
+			unknown = append(unknown, o)
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		members, err := userRepo.ListActiveByTeam(ctx, o)
+		if err != nil {
+			return nil, nil, err
+		}
+		result[o] = members
+	}
+
+	return result, unknown, nil
+}
+
+// codeownerCandidates returns the owners of files according to the CODEOWNERS
+// file of teamName, together with the rule that made each of them an owner.
+// Files are considered in order, so owners of earlier files come first.
+func (s *prService) codeownerCandidates(ctx context.Context, teamName string, files []string) ([]*domain.User, map[string]*domain.CodeownersRule, error) {
+	if len(files) == 0 {
+		return nil, nil, nil
+	}
+
+	file, err := s.codeownersRepo.Get(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, nil
+		}
+		return nil, nil, err
+	}
+
+	rs, _, err := codeowners.Parse(strings.NewReader(file.Content))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		matched []*domain.CodeownersRule
+		owners  []string
+		seen    = make(map[string]bool)
+	)
+	for _, f := range files {
+		rule := rs.Match(f)
+		if rule == nil {
+			continue
+		}
+		matched = append(matched, rule)
+		for _, o := range rule.Owners {
+			if !seen[o] {
+				seen[o] = true
+				owners = append(owners, o)
+			}
+		}
+	}
+	if len(owners) == 0 {
+		return nil, nil, nil
+	}
+
+	resolved, _, err := resolveOwners(ctx, s.userRepo, s.teamRepo, owners)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var users []*domain.User
+	rules := make(map[string]*domain.CodeownersRule)
+	for _, rule := range matched {
+		for _, o := range rule.Owners {
+			for _, u := range resolved[o] {
+				if _, ok := rules[u.ID]; ok {
+					continue
+				}
+				rules[u.ID] = rule
+				users = append(users, u)
+			}
+		}
+	}
+
+	return users, rules, nil
+}
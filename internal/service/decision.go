@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type decisionKey struct{}
+
+// decisionRecorder collects an assignment decision while reviewers are
+// picked. All randomness of the selection comes from its seeded source, so
+// picking again from the same data with the same seed gives the same result.
+type decisionRecorder struct {
+	decision domain.AssignmentDecision
+	rng      *rand.Rand
+	// reasons are the declared reasons for users passed as exclude to the selector.
+	reasons map[string]domain.ExclusionReason
+}
+
+func newDecisionRecorder(kind domain.DecisionKind, seed int64) *decisionRecorder {
+	return &decisionRecorder{
+		decision: domain.AssignmentDecision{
+			Kind:       kind,
+			Strategy:   domain.DecisionStrategyRandom,
+			Seed:       seed,
+			Candidates: []string{},
+			Exclusions: []domain.Exclusion{},
+			Selected:   []string{},
+		},
+		rng:     rand.New(rand.NewSource(seed)),
+		reasons: make(map[string]domain.ExclusionReason),
+	}
+}
+
+// newSeed returns a random seed for a new decision.
+func newSeed() int64 {
+	return rand.Int63()
+}
+
+func withDecision(ctx context.Context, d *decisionRecorder) context.Context {
+	return context.WithValue(ctx, decisionKey{}, d)
+}
+
+// decisionFrom returns the recorder of ctx. Without one a throwaway recorder
+// with a random seed is returned, so the selector never has to check.
+func decisionFrom(ctx context.Context) *decisionRecorder {
+	if d, ok := ctx.Value(decisionKey{}).(*decisionRecorder); ok {
+		return d
+	}
+	return newDecisionRecorder("", newSeed())
+}
+
+func (d *decisionRecorder) shuffle(users []*domain.User) {
+	d.rng.Shuffle(len(users), func(i, j int) {
+		users[i], users[j] = users[j], users[i]
+	})
+}
+
+// excludeAs declares why ids will be excluded. Excluded users without a
+// declared reason are taken to be assigned already.
+func (d *decisionRecorder) excludeAs(reason domain.ExclusionReason, ids ...string) {
+	for _, id := range ids {
+		if _, ok := d.reasons[id]; !ok {
+			d.reasons[id] = reason
+		}
+	}
+}
+
+func (d *decisionRecorder) reasonFor(id string) domain.ExclusionReason {
+	if reason, ok := d.reasons[id]; ok {
+		return reason
+	}
+	return domain.ExclusionAlreadyAssigned
+}
+
+// exclude records that id was not a candidate. Users met again in a later
+// step keep the first outcome.
+func (d *decisionRecorder) exclude(id string, reason domain.ExclusionReason) {
+	if d.known(id) {
+		return
+	}
+	d.decision.Exclusions = append(d.decision.Exclusions, domain.Exclusion{UserID: id, Reason: reason})
+}
+
+// candidate records that id was among the users reviewers were drawn from.
+// It overrides an earlier exclusion, since a later step may still accept a
+// user, e.g. the least loaded fallback.
+func (d *decisionRecorder) candidate(id string) {
+	if slices.Contains(d.decision.Candidates, id) {
+		return
+	}
+	d.decision.Exclusions = slices.DeleteFunc(d.decision.Exclusions, func(e domain.Exclusion) bool {
+		return e.UserID == id
+	})
+	d.decision.Candidates = append(d.decision.Candidates, id)
+}
+
+func (d *decisionRecorder) known(id string) bool {
+	return slices.Contains(d.decision.Candidates, id) ||
+		slices.ContainsFunc(d.decision.Exclusions, func(e domain.Exclusion) bool { return e.UserID == id })
+}
+
+// useStrategy records strategy unless a more specific one was recorded:
+// tag coverage over the least loaded fallback over random.
+func (d *decisionRecorder) useStrategy(strategy domain.DecisionStrategy) {
+	rank := func(s domain.DecisionStrategy) int {
+		switch s {
+		case domain.DecisionStrategyTagCoverage:
+			return 2
+		case domain.DecisionStrategyLeastLoaded:
+			return 1
+		default:
+			return 0
+		}
+	}
+	if rank(strategy) > rank(d.decision.Strategy) {
+		d.decision.Strategy = strategy
+	}
+}
+
+// finish completes the decision for pullRequestID with the selected reviewers.
+func (d *decisionRecorder) finish(ctx context.Context, pullRequestID string, selected []string, at time.Time) *domain.AssignmentDecision {
+	d.decision.PullRequestID = pullRequestID
+	d.decision.Selected = append([]string{}, selected...)
+	d.decision.Actor = actorID(ctx)
+	d.decision.CreatedAt = at
+	return &d.decision
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// Worker is a background process whose liveness is part of readiness.
+type Worker interface {
+	Name() string
+	Running() bool
+}
+
+type HealthService interface {
+	Liveness(ctx context.Context) *domain.HealthReport
+	Readiness(ctx context.Context) *domain.HealthReport
+	RegisterWorker(w Worker)
+	BeginShutdown()
+}
+
+type healthService struct {
+	repo repository.HealthRepository
+
+	mu      sync.RWMutex
+	workers []Worker
+
+	shuttingDown atomic.Bool
+}
+
+func NewHealthService(repo repository.HealthRepository) HealthService {
+	return &healthService{
+		repo: repo,
+	}
+}
+
+func (s *healthService) Liveness(ctx context.Context) *domain.HealthReport {
+	return newHealthReport([]domain.HealthCheck{
+		runCheck("process", func() error { return nil }),
+	})
+}
+
+func (s *healthService) Readiness(ctx context.Context) *domain.HealthReport {
+	checks := []domain.HealthCheck{
+		runCheck("shutdown", func() error {
+			if s.shuttingDown.Load() {
+				return fmt.Errorf("server is shutting down")
+			}
+			return nil
+		}),
+		runCheck("postgres", func() error {
+			return s.repo.Ping(ctx)
+		}),
+		runCheck("migrations", func() error {
+			version, err := s.repo.SchemaVersion(ctx)
+			if err != nil {
+				return err
+			}
+			if version < repository.SchemaVersion {
+				return fmt.Errorf("schema version %d, expected %d", version, repository.SchemaVersion)
+			}
+			return nil
+		}),
+	}
+
+	s.mu.RLock()
+	workers := append([]Worker(nil), s.workers...)
+	s.mu.RUnlock()
+
+	for _, w := range workers {
+		checks = append(checks, runCheck("worker:"+w.Name(), func() error {
+			if !w.Running() {
+				return fmt.Errorf("worker is not running")
+			}
+			return nil
+		}))
+	}
+
+	return newHealthReport(checks)
+}
+
+func (s *healthService) RegisterWorker(w Worker) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.workers = append(s.workers, w)
+}
+
+func (s *healthService) BeginShutdown() {
+	s.shuttingDown.Store(true)
+}
+
+func runCheck(name string, fn func() error) domain.HealthCheck {
+	start := time.Now()
+	err := fn()
+
+	check := domain.HealthCheck{
+		Name:      name,
+		Status:    domain.HealthStatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		check.Status = domain.HealthStatusFail
+		check.Error = err.Error()
+	}
+
+	return check
+}
+
+func newHealthReport(checks []domain.HealthCheck) *domain.HealthReport {
+	report := &domain.HealthReport{
+		Status: domain.HealthStatusOK,
+		Checks: checks,
+	}
+	for _, c := range checks {
+		if c.Status != domain.HealthStatusOK {
+			report.Status = domain.HealthStatusFail
+			break
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,299 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// ReviewerChangeInput names a reviewer of a pull request.
+type ReviewerChangeInput struct {
+	PullRequestID string
+	ReviewerID    string
+}
+
+// SwapReviewersInput names two reviewers on two pull requests that trade places.
+type SwapReviewersInput struct {
+	First  ReviewerChangeInput
+	Second ReviewerChangeInput
+}
+
+// AddReviewer assigns a named reviewer to an open pull request. Review limits
+// and out-of-office windows are not checked, since a lead chose the reviewer,
+// but the never-pair rules of the author's team are. A shadow reviewer added
+// this way becomes a regular one.
+func (s *prService) AddReviewer(ctx context.Context, input ReviewerChangeInput) (*domain.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PRService.AddReviewer")
+	defer span.End()
+
+	pr, rules, err := s.openPRForOverride(ctx, input.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewer, err := s.manualReviewer(ctx, pr, rules, input.ReviewerID)
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Reviewers = append(pr.Reviewers, reviewer.ID)
+	pr.ShadowReviewers = slices.DeleteFunc(pr.ShadowReviewers, func(id string) bool { return id == reviewer.ID })
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return s.prRepo.CreateEvent(ctx, &domain.PREvent{
+			PullRequestID: pr.ID,
+			Type:          domain.PREventReviewerAdded,
+			Actor:         actorID(ctx),
+			NewReviewerID: reviewer.ID,
+			CreatedAt:     time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("reviewer added",
+		zap.String("pull_request_id", pr.ID),
+		zap.String("reviewer_id", reviewer.ID),
+		zap.String("actor", actorID(ctx)),
+	)
+
+	return pr, nil
+}
+
+// RemoveReviewer unassigns a reviewer from an open pull request without a
+// replacement. The last reviewer, mandatory reviewers of the author's team and
+// the only senior of a team requiring one cannot be removed.
+func (s *prService) RemoveReviewer(ctx context.Context, input ReviewerChangeInput) (*domain.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PRService.RemoveReviewer")
+	defer span.End()
+
+	pr, rules, err := s.openPRForOverride(ctx, input.PullRequestID)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := slices.Index(pr.Reviewers, input.ReviewerID)
+	if idx == -1 {
+		return nil, domain.ErrNotAssigned
+	}
+	if len(pr.Reviewers) == 1 {
+		return nil, domain.ErrLastReviewer
+	}
+	if isMandatory(rules, input.ReviewerID) {
+		return nil, domain.ErrMandatoryReviewer
+	}
+	if err := s.checkSenior(ctx, pr, rules, input.ReviewerID, nil); err != nil {
+		return nil, err
+	}
+	pr.Reviewers = slices.Delete(pr.Reviewers, idx, idx+1)
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return s.prRepo.CreateEvent(ctx, &domain.PREvent{
+			PullRequestID: pr.ID,
+			Type:          domain.PREventReviewerRemoved,
+			Actor:         actorID(ctx),
+			OldReviewerID: input.ReviewerID,
+			CreatedAt:     time.Now(),
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("reviewer removed",
+		zap.String("pull_request_id", pr.ID),
+		zap.String("reviewer_id", input.ReviewerID),
+		zap.String("actor", actorID(ctx)),
+	)
+
+	return pr, nil
+}
+
+// SwapReviewers moves the first reviewer to the second pull request and the
+// second reviewer to the first one. Each reviewer must be able to review the
+// pull request they move to, as for AddReviewer. A mandatory reviewer may only
+// be swapped for another mandatory reviewer of the same team, and the only
+// senior of a team requiring one only for another senior.
+func (s *prService) SwapReviewers(ctx context.Context, input SwapReviewersInput) (*domain.PullRequest, *domain.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PRService.SwapReviewers")
+	defer span.End()
+
+	first, firstRules, err := s.openPRForOverride(ctx, input.First.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+	second, secondRules, err := s.openPRForOverride(ctx, input.Second.PullRequestID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	firstIdx := slices.Index(first.Reviewers, input.First.ReviewerID)
+	secondIdx := slices.Index(second.Reviewers, input.Second.ReviewerID)
+	if firstIdx == -1 || secondIdx == -1 {
+		return nil, nil, domain.ErrNotAssigned
+	}
+	if isMandatory(firstRules, input.First.ReviewerID) && !isMandatory(firstRules, input.Second.ReviewerID) ||
+		isMandatory(secondRules, input.Second.ReviewerID) && !isMandatory(secondRules, input.First.ReviewerID) {
+		return nil, nil, domain.ErrMandatoryReviewer
+	}
+
+	toFirst, err := s.manualReviewer(ctx, first, firstRules, input.Second.ReviewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	toSecond, err := s.manualReviewer(ctx, second, secondRules, input.First.ReviewerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkSenior(ctx, first, firstRules, toSecond.ID, toFirst); err != nil {
+		return nil, nil, err
+	}
+	if err := s.checkSenior(ctx, second, secondRules, toFirst.ID, toSecond); err != nil {
+		return nil, nil, err
+	}
+
+	first.Reviewers[firstIdx] = toFirst.ID
+	first.ShadowReviewers = slices.DeleteFunc(first.ShadowReviewers, func(id string) bool { return id == toFirst.ID })
+	second.Reviewers[secondIdx] = toSecond.ID
+	second.ShadowReviewers = slices.DeleteFunc(second.ShadowReviewers, func(id string) bool { return id == toSecond.ID })
+
+	now := time.Now()
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, pr := range []*domain.PullRequest{first, second} {
+			if err := s.prRepo.Update(ctx, pr); err != nil {
+				return err
+			}
+		}
+		if err := s.prRepo.CreateEvent(ctx, &domain.PREvent{
+			PullRequestID: first.ID,
+			Type:          domain.PREventReviewerSwapped,
+			Actor:         actorID(ctx),
+			OldReviewerID: toSecond.ID,
+			NewReviewerID: toFirst.ID,
+			CreatedAt:     now,
+		}); err != nil {
+			return err
+		}
+		return s.prRepo.CreateEvent(ctx, &domain.PREvent{
+			PullRequestID: second.ID,
+			Type:          domain.PREventReviewerSwapped,
+			Actor:         actorID(ctx),
+			OldReviewerID: toFirst.ID,
+			NewReviewerID: toSecond.ID,
+			CreatedAt:     now,
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.FromContext(ctx).Info("reviewers swapped",
+		zap.String("first_pull_request_id", first.ID),
+		zap.String("second_pull_request_id", second.ID),
+		zap.String("first_reviewer_id", toSecond.ID),
+		zap.String("second_reviewer_id", toFirst.ID),
+		zap.String("actor", actorID(ctx)),
+	)
+
+	return first, second, nil
+}
+
+// openPRForOverride loads an open pull request whose reviewers the caller may
+// change by hand, which is left to the leads of the author's team, together
+// with the review rules of that team.
+func (s *prService) openPRForOverride(ctx context.Context, id string) (*domain.PullRequest, *domain.ReviewRules, error) {
+	pr, err := s.prRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+	if err := authorize(ctx, actionConfigureTeam, author.TeamName); err != nil {
+		return nil, nil, err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, nil, domain.ErrPRMerged
+	}
+
+	rules, err := s.reviewRulesRepo.Get(ctx, author.TeamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return pr, rules, nil
+}
+
+// checkSenior fails with domain.ErrSeniorRequired if rules require a senior
+// and outgoing is the only senior reviewing pr, unless incoming, the reviewer
+// taking the place, is a senior too. incoming is nil when nobody takes it.
+func (s *prService) checkSenior(ctx context.Context, pr *domain.PullRequest, rules *domain.ReviewRules, outgoing string, incoming *domain.User) error {
+	if !rules.RequireSenior || incoming != nil && incoming.Seniority == domain.SenioritySenior {
+		return nil
+	}
+
+	reviewers, err := s.userRepo.ListByIDs(ctx, pr.Reviewers)
+	if err != nil {
+		return err
+	}
+	var seniors []string
+	for _, u := range reviewers {
+		if u.Seniority == domain.SenioritySenior {
+			seniors = append(seniors, u.ID)
+		}
+	}
+	if len(seniors) == 1 && seniors[0] == outgoing {
+		return domain.ErrSeniorRequired
+	}
+
+	return nil
+}
+
+// manualReviewer loads the user with id and checks that they can be put on
+// pr by hand: not its author, not never-paired with the author by rules,
+// active and not reviewing it already.
+func (s *prService) manualReviewer(ctx context.Context, pr *domain.PullRequest, rules *domain.ReviewRules, id string) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	switch {
+	case user.ID == pr.AuthorID:
+		return nil, domain.ErrReviewerIsAuthor
+	case slices.Contains(neverPaired(rules, pr.AuthorID), user.ID):
+		return nil, domain.ErrNeverPaired
+	case !user.IsActive || user.Deleted():
+		return nil, domain.ErrReviewerInactive
+	case slices.Contains(pr.Reviewers, user.ID):
+		return nil, domain.ErrAlreadyAssigned
+	}
+
+	return user, nil
+}
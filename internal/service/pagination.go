@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 100
+)
+
+// ListPRsInput describes a page request; Cursor is the opaque next_cursor of the previous page.
+type ListPRsInput struct {
+	AuthorID     string
+	TeamName     string
+	Status       domain.PRStatus
+	CreatedAfter *time.Time
+	Sort         domain.SortOrder
+	Cursor       string
+	Limit        int
+	// Unpaged returns every matching pull request when neither Limit nor
+	// Cursor is set. v1 endpoints that predate pagination rely on it.
+	Unpaged bool
+}
+
+type cursorToken struct {
+	CreatedAt time.Time        `json:"t"`
+	ID        string           `json:"id"`
+	Sort      domain.SortOrder `json:"s"`
+}
+
+func encodeCursor(pr domain.PullRequestShort, sort domain.SortOrder) string {
+	token := cursorToken{ID: pr.ID, Sort: sort}
+	if pr.CreatedAt != nil {
+		token.CreatedAt = *pr.CreatedAt
+	}
+
+	raw, _ := json.Marshal(token)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+type userCursorToken struct {
+	Username string `json:"u"`
+	ID       string `json:"id"`
+}
+
+func encodeUserCursor(u domain.User) string {
+	raw, _ := json.Marshal(userCursorToken{Username: u.Username, ID: u.ID})
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeUserCursor(s string) (*domain.UserCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var token userCursorToken
+	if err := json.Unmarshal(raw, &token); err != nil || token.ID == "" {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &domain.UserCursor{Username: token.Username, ID: token.ID}, nil
+}
+
+// pageLimit applies the default and maximum page size.
+func pageLimit(limit int) int {
+	if limit <= 0 {
+		return DefaultPageSize
+	}
+	if limit > MaxPageSize {
+		return MaxPageSize
+	}
+	return limit
+}
+
+// decodeCursor rejects malformed tokens and tokens issued for a different sort order.
+func decodeCursor(s string, sort domain.SortOrder) (*domain.PRCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	var token cursorToken
+	if err := json.Unmarshal(raw, &token); err != nil || token.ID == "" || token.Sort != sort {
+		return nil, domain.ErrInvalidCursor
+	}
+
+	return &domain.PRCursor{CreatedAt: token.CreatedAt, ID: token.ID}, nil
+}
+
+// listPage applies input on top of filter, fetches one page and sets the cursor of the next one.
+func (s *prService) listPage(ctx context.Context, filter domain.PRListFilter, input ListPRsInput) (*domain.PRPage, error) {
+	filter.AuthorID = input.AuthorID
+	filter.TeamName = input.TeamName
+	filter.Status = input.Status
+	filter.CreatedAfter = input.CreatedAfter
+
+	filter.Sort = input.Sort
+	if filter.Sort == "" {
+		filter.Sort = domain.SortDesc
+	}
+
+	if input.Unpaged && input.Limit == 0 && input.Cursor == "" {
+		prs, err := s.prRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		return &domain.PRPage{PullRequests: prs}, nil
+	}
+
+	limit := pageLimit(input.Limit)
+	filter.Limit = limit + 1
+
+	if input.Cursor != "" {
+		after, err := decodeCursor(input.Cursor, filter.Sort)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	prs, err := s.prRepo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.PRPage{PullRequests: prs}
+	if len(prs) > limit {
+		page.PullRequests = prs[:limit]
+		page.NextCursor = encodeCursor(prs[limit-1], filter.Sort)
+	}
+
+	return page, nil
+}
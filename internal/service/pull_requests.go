@@ -3,17 +3,29 @@ package service
 import (
 	"context"
 	"errors"
-	"math/rand"
+	"math"
+	"slices"
 	"time"
 
+	"go.uber.org/zap"
+
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 	"github.com/CodebyTecs/pr-assign-service/internal/repository"
 )
 
+// reviewerCount is the number of reviewers assigned to a new pull request.
+const reviewerCount = 2
+
+// CreatePRInput describes a new pull request. Files are the changed paths,
+// used to prefer CODEOWNERS of the author's team. RequiredTags are expertise
+// tags that the assigned reviewers should cover.
 type CreatePRInput struct {
-	ID     string
-	Name   string
-	Author string
+	ID           string
+	Name         string
+	Author       string
+	Files        []string
+	RequiredTags []string
 }
 
 type ReassignReviewerInput struct {
@@ -21,95 +33,457 @@ type ReassignReviewerInput struct {
 	ReviewerID    string
 }
 
+// DeclineReviewInput is a reviewer handing a review back with a reason.
+type DeclineReviewInput struct {
+	PullRequestID string
+	ReviewerID    string
+	Reason        domain.DeclineReason
+}
+
 type PRService interface {
-	Create(ctx context.Context, input CreatePRInput) (*domain.PullRequest, error)
+	Create(ctx context.Context, input CreatePRInput) (*domain.PullRequest, []domain.AssignedReviewer, error)
+	Get(ctx context.Context, id string) (*domain.PullRequestDetails, error)
 	Merge(ctx context.Context, id string) (*domain.PullRequest, error)
-	Reassign(ctx context.Context, input ReassignReviewerInput) (*domain.PullRequest, string, error)
-	ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error)
+	Reassign(ctx context.Context, input ReassignReviewerInput) (*domain.PullRequest, *domain.AssignedReviewer, error)
+	Decline(ctx context.Context, input DeclineReviewInput) (*domain.PullRequest, *domain.AssignedReviewer, error)
+	ListByReviewer(ctx context.Context, reviewerID string, input ListPRsInput) (*domain.PRPage, error)
+	List(ctx context.Context, input ListPRsInput) (*domain.PRPage, error)
+	Preview(ctx context.Context, input CreatePRInput) (*domain.AssignmentPreview, error)
+	ListDecisions(ctx context.Context, id string) ([]domain.AssignmentDecision, error)
+	AddReviewer(ctx context.Context, input ReviewerChangeInput) (*domain.PullRequest, error)
+	RemoveReviewer(ctx context.Context, input ReviewerChangeInput) (*domain.PullRequest, error)
+	SwapReviewers(ctx context.Context, input SwapReviewersInput) (*domain.PullRequest, *domain.PullRequest, error)
 }
 
 type prService struct {
-	prRepo   repository.PRRepository
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
+	prRepo          repository.PRRepository
+	userRepo        repository.UserRepository
+	teamRepo        repository.TeamRepository
+	codeownersRepo  repository.CodeownersRepository
+	reviewRulesRepo repository.ReviewRulesRepository
+	tx              repository.Transactor
+	selector        *reviewerSelector
 }
 
-func NewPRService(prRepo repository.PRRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository) PRService {
+func NewPRService(prRepo repository.PRRepository, userRepo repository.UserRepository, teamRepo repository.TeamRepository, unavailabilityRepo repository.UnavailabilityRepository, codeownersRepo repository.CodeownersRepository, reviewRulesRepo repository.ReviewRulesRepository, tx repository.Transactor) PRService {
 	return &prService{
-		prRepo:   prRepo,
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		prRepo:          prRepo,
+		userRepo:        userRepo,
+		teamRepo:        teamRepo,
+		codeownersRepo:  codeownersRepo,
+		reviewRulesRepo: reviewRulesRepo,
+		tx:              tx,
+		selector:        newReviewerSelector(userRepo, teamRepo, prRepo, unavailabilityRepo, reviewRulesRepo),
 	}
 }
 
-func (s *prService) Create(ctx context.Context, input CreatePRInput) (*domain.PullRequest, error) {
+func (s *prService) Create(ctx context.Context, input CreatePRInput) (*domain.PullRequest, []domain.AssignedReviewer, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Create")
+	defer span.End()
+
 	_, err := s.prRepo.GetByID(ctx, input.ID)
 	if err == nil {
-		return nil, domain.ErrPRExists
+		return nil, nil, domain.ErrPRExists
 	} else if !errors.Is(err, repository.ErrNotFound) {
+		return nil, nil, err
+	}
+
+	author, err := s.author(ctx, input.Author)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := authorize(ctx, actionManagePRs, author.TeamName); err != nil {
+		return nil, nil, err
+	}
+
+	decision := newDecisionRecorder(domain.DecisionKindCreate, newSeed())
+	ctx = withDecision(ctx, decision)
+
+	now := time.Now()
+	requiredTags, assigned, err := s.propose(ctx, author, input, now)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pr := &domain.PullRequest{
+		ID:           input.ID,
+		Name:         input.Name,
+		AuthorID:     input.Author,
+		Status:       domain.PRStatusOpen,
+		Reviewers:    make([]string, 0, len(assigned)),
+		RequiredTags: requiredTags,
+		CreatedAt:    &now,
+	}
+	for _, a := range assigned {
+		if a.Role == domain.ReviewerRoleShadow {
+			pr.ShadowReviewers = append(pr.ShadowReviewers, a.UserID)
+		} else {
+			pr.Reviewers = append(pr.Reviewers, a.UserID)
+		}
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Create(ctx, pr); err != nil {
+			return err
+		}
+		return s.prRepo.CreateDecision(ctx, decision.finish(ctx, pr.ID, pr.Reviewers, now))
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.FromContext(ctx).Info("pull request created",
+		zap.String("pull_request_id", pr.ID),
+		zap.String("author_id", pr.AuthorID),
+		zap.Strings("reviewers", pr.Reviewers),
+		zap.Strings("shadow_reviewers", pr.ShadowReviewers),
+	)
+
+	return pr, assigned, nil
+}
+
+// Preview runs the reviewer selection of Create without storing anything.
+// When every candidate is at capacity the preview has no reviewers rather
+// than failing, and its exclusions tell why.
+func (s *prService) Preview(ctx context.Context, input CreatePRInput) (*domain.AssignmentPreview, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Preview")
+	defer span.End()
+
+	author, err := s.author(ctx, input.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := authorize(ctx, actionManagePRs, author.TeamName); err != nil {
 		return nil, err
 	}
 
-	author, err := s.userRepo.GetByID(ctx, input.Author)
+	decision := newDecisionRecorder(domain.DecisionKindCreate, newSeed())
+	ctx = withDecision(ctx, decision)
+
+	_, assigned, err := s.propose(ctx, author, input, time.Now())
+	if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+		return nil, err
+	}
+
+	preview := &domain.AssignmentPreview{
+		AuthorID:   author.ID,
+		Reviewers:  append([]domain.AssignedReviewer{}, assigned...),
+		Alternates: []string{},
+		Exclusions: decision.decision.Exclusions,
+		Strategy:   decision.decision.Strategy,
+		Seed:       decision.decision.Seed,
+	}
+	for _, id := range decision.decision.Candidates {
+		if !slices.ContainsFunc(assigned, func(a domain.AssignedReviewer) bool { return a.UserID == id }) {
+			preview.Alternates = append(preview.Alternates, id)
+		}
+	}
+
+	return preview, nil
+}
+
+// author returns the author of a new pull request; offboarded users cannot author one.
+func (s *prService) author(ctx context.Context, id string) (*domain.User, error) {
+	author, err := s.userRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
+	if author.Deleted() {
+		return nil, domain.ErrNotFound
+	}
+
+	return author, nil
+}
 
+// propose selects the reviewers of a new pull request by author and, if the
+// team mentors, a shadow reviewer marked by its role. It returns the
+// normalized required tags along with them. Nothing is stored.
+func (s *prService) propose(ctx context.Context, author *domain.User, input CreatePRInput, at time.Time) ([]string, []domain.AssignedReviewer, error) {
 	team, err := s.teamRepo.GetByName(ctx, author.TeamName)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return nil, domain.ErrNotFound
+			return nil, nil, domain.ErrNotFound
 		}
+		return nil, nil, err
+	}
+
+	requiredTags := slices.Clone(input.RequiredTags)
+	slices.Sort(requiredTags)
+	requiredTags = slices.Compact(requiredTags)
+
+	if err := checkTags(ctx, s.userRepo, requiredTags); err != nil {
+		return nil, nil, err
+	}
+
+	assigned, err := s.assignReviewers(ctx, author, input.Files, requiredTags, at)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if team.Mentoring {
+		reviewers := make([]string, 0, len(assigned))
+		for _, a := range assigned {
+			reviewers = append(reviewers, a.UserID)
+		}
+		shadow, err := s.assignShadow(ctx, author, reviewers, at)
+		if err != nil {
+			return nil, nil, err
+		}
+		if shadow != nil {
+			assigned = append(assigned, *shadow)
+		}
+	}
+
+	return requiredTags, assigned, nil
+}
+
+// assignReviewers picks the reviewers of a new pull request by author. The
+// team's mandatory reviewers come first. Owners of the changed files are
+// preferred for the remaining places, which are then filled from the author's
+// team and, if it runs short, from its partner teams. When tags are required,
+// candidates covering the most uncovered tags are taken first, so that each
+// tag gets a reviewer if anyone in the pool has it. Reviewers the team forbids
+// for the author are never picked, and a senior is swapped in if the team
+// requires one.
+func (s *prService) assignReviewers(ctx context.Context, author *domain.User, files, tags []string, at time.Time) ([]domain.AssignedReviewer, error) {
+	rules, err := s.reviewRulesRepo.Get(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := append([]string{author.ID}, neverPaired(rules, author.ID)...)
+
+	decision := decisionFrom(ctx)
+	decision.excludeAs(domain.ExclusionAuthor, author.ID)
+	decision.excludeAs(domain.ExclusionNeverPair, neverPaired(rules, author.ID)...)
+	if len(tags) > 0 {
+		decision.useStrategy(domain.DecisionStrategyTagCoverage)
+	}
+
+	mandatory, err := s.mandatoryReviewers(ctx, rules, files, at, exclude...)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range mandatory {
+		exclude = append(exclude, m.UserID)
+	}
+
+	slots := max(reviewerCount-len(mandatory), 0)
+
+	pool, atCapacity, err := s.candidatePool(ctx, author, files, slots, len(tags) > 0, at, exclude...)
+	if err != nil {
 		return nil, err
 	}
+	if atCapacity && len(pool) == 0 && len(mandatory) == 0 {
+		return nil, domain.ErrAllAtCapacity
+	}
 
-	users, err := s.userRepo.ListActiveByTeam(ctx, author.TeamName)
+	assigned, err := s.coverTags(ctx, mandatory, pool, tags, slots)
 	if err != nil {
 		return nil, err
 	}
 
-	candidates := make([]*domain.User, 0, len(team.Members))
-	for _, u := range users {
-		if u.ID == author.ID {
-			continue
+	return s.ensureSenior(ctx, rules, assigned, len(mandatory), at, exclude...)
+}
+
+// candidatePool returns the candidates for up to n places: owners of files
+// first, then the author's team and, if those are not enough, partner teams.
+// With all set the whole owner and team pools are returned rather than n
+// candidates. atCapacity reports that the team rejected every candidate
+// because of their review limits.
+func (s *prService) candidatePool(ctx context.Context, author *domain.User, files []string, n int, all bool, at time.Time, exclude ...string) (pool []domain.AssignedReviewer, atCapacity bool, err error) {
+	if n == 0 {
+		return nil, false, nil
+	}
+
+	owners, ownerRules, err := s.codeownerCandidates(ctx, author.TeamName, files)
+	if err != nil {
+		return nil, false, err
+	}
+
+	want := n
+	if all {
+		want = math.MaxInt
+	}
+
+	fromOwners, err := s.selector.pickFrom(ctx, owners, at, want, exclude...)
+	if err != nil {
+		return nil, false, err
+	}
+
+	exclude = slices.Clone(exclude)
+	for _, u := range fromOwners {
+		pool = append(pool, domain.AssignedReviewer{UserID: u.ID, Source: domain.ReviewerSourceCodeowners, Rule: ownerRules[u.ID]})
+		exclude = append(exclude, u.ID)
+	}
+
+	if len(pool) >= n && !all {
+		return pool, false, nil
+	}
+
+	rest, err := s.selector.pick(ctx, author.TeamName, at, want-len(pool), exclude...)
+	atCapacity = errors.Is(err, domain.ErrAllAtCapacity)
+	if err != nil && !atCapacity {
+		return nil, false, err
+	}
+	for _, u := range rest {
+		pool = append(pool, domain.AssignedReviewer{UserID: u.ID, Source: domain.ReviewerSourceTeam})
+		exclude = append(exclude, u.ID)
+	}
+
+	if len(pool) < n {
+		partners, err := s.selector.pickPartners(ctx, author.TeamName, at, n-len(pool), exclude...)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, u := range partners {
+			pool = append(pool, domain.AssignedReviewer{UserID: u.ID, Source: domain.ReviewerSourcePartner, TeamName: u.TeamName})
 		}
-		candidates = append(candidates, u)
 	}
 
-	selected := candidates
-	if len(selected) > 2 {
-		rand.Shuffle(len(selected), func(i, j int) {
-			selected[i], selected[j] = selected[j], selected[i]
+	return pool, atCapacity, nil
+}
+
+// assignShadow picks a junior of the author's team to shadow the reviewers of
+// a new pull request. Juniors the team forbids for the author are skipped. It
+// returns nil if there is no junior to pick.
+func (s *prService) assignShadow(ctx context.Context, author *domain.User, reviewers []string, at time.Time) (*domain.AssignedReviewer, error) {
+	rules, err := s.reviewRulesRepo.Get(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := slices.Concat([]string{author.ID}, reviewers, neverPaired(rules, author.ID))
+
+	shadow, err := s.selector.pickShadow(ctx, author.TeamName, at, exclude...)
+	if err != nil || shadow == nil {
+		return nil, err
+	}
+
+	return &domain.AssignedReviewer{UserID: shadow.ID, Source: domain.ReviewerSourceTeam, Role: domain.ReviewerRoleShadow}, nil
+}
+
+// coverTags appends up to n reviewers from pool to fixed, greedily taking the
+// candidate who covers the most still uncovered tags; tags of the fixed
+// reviewers count as covered. Ties and the places left once no candidate adds
+// a tag go by pool order.
+func (s *prService) coverTags(ctx context.Context, fixed, pool []domain.AssignedReviewer, tags []string, n int) ([]domain.AssignedReviewer, error) {
+	assigned := slices.Clone(fixed)
+	if len(tags) == 0 {
+		return append(assigned, pool[:min(n, len(pool))]...), nil
+	}
+
+	ids := make([]string, 0, len(fixed)+len(pool))
+	for _, c := range slices.Concat(fixed, pool) {
+		ids = append(ids, c.UserID)
+	}
+	userTags, err := s.userRepo.TagsByUsers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	uncovered := slices.Clone(tags)
+	take := func(reviewer domain.AssignedReviewer) domain.AssignedReviewer {
+		reviewer.CoveredTags = nil
+		for _, tag := range uncovered {
+			if slices.Contains(userTags[reviewer.UserID], tag) {
+				reviewer.CoveredTags = append(reviewer.CoveredTags, tag)
+			}
+		}
+		return reviewer
+	}
+	cover := func(reviewer domain.AssignedReviewer) {
+		uncovered = slices.DeleteFunc(uncovered, func(tag string) bool {
+			return slices.Contains(reviewer.CoveredTags, tag)
 		})
-		selected = selected[:2]
 	}
 
-	selectedReviewers := make([]string, 0, len(selected))
-	for _, c := range selected {
-		selectedReviewers = append(selectedReviewers, c.ID)
+	for i := range assigned {
+		assigned[i] = take(assigned[i])
+		cover(assigned[i])
 	}
 
-	now := time.Now()
-	pr := &domain.PullRequest{
-		ID:        input.ID,
-		Name:      input.Name,
-		AuthorID:  input.Author,
-		Status:    domain.PRStatusOpen,
-		Reviewers: selectedReviewers,
-		CreatedAt: &now,
+	taken := make([]bool, len(pool))
+	for picked := 0; picked < n && picked < len(pool); picked++ {
+		best, bestReviewer := -1, domain.AssignedReviewer{}
+		for i, c := range pool {
+			if taken[i] {
+				continue
+			}
+			reviewer := take(c)
+			if best == -1 || len(reviewer.CoveredTags) > len(bestReviewer.CoveredTags) {
+				best, bestReviewer = i, reviewer
+			}
+		}
+
+		taken[best] = true
+		assigned = append(assigned, bestReviewer)
+		cover(bestReviewer)
+	}
+
+	if len(uncovered) > 0 {
+		logger.FromContext(ctx).Warn("required tags not covered by reviewers",
+			zap.Strings("tags", uncovered),
+		)
 	}
 
-	if err := s.prRepo.Create(ctx, pr); err != nil {
+	return assigned, nil
+}
+
+func (s *prService) Get(ctx context.Context, id string) (*domain.PullRequestDetails, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Get")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
 		return nil, err
 	}
 
-	return pr, nil
+	pr, err := s.prRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	ids := append([]string{pr.AuthorID}, pr.Reviewers...)
+	users, err := s.userRepo.ListByIDs(ctx, append(ids, pr.ShadowReviewers...))
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]*domain.User, len(users))
+	for _, u := range users {
+		byID[u.ID] = u
+	}
+
+	details := &domain.PullRequestDetails{
+		PullRequest:     *pr,
+		Author:          byID[pr.AuthorID],
+		ReviewerDetails: make([]domain.PullRequestReviewer, 0, len(pr.Reviewers)+len(pr.ShadowReviewers)),
+	}
+	addReviewers := func(ids []string, role domain.ReviewerRole) {
+		for _, id := range ids {
+			if u, ok := byID[id]; ok {
+				details.ReviewerDetails = append(details.ReviewerDetails, domain.PullRequestReviewer{User: *u, Role: role})
+			}
+		}
+	}
+	addReviewers(pr.Reviewers, domain.ReviewerRoleReviewer)
+	addReviewers(pr.ShadowReviewers, domain.ReviewerRoleShadow)
+
+	return details, nil
 }
 
 func (s *prService) Merge(ctx context.Context, id string) (*domain.PullRequest, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Merge")
+	defer span.End()
+
 	pr, err := s.prRepo.GetByID(ctx, id)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -118,6 +492,10 @@ func (s *prService) Merge(ctx context.Context, id string) (*domain.PullRequest,
 		return nil, err
 	}
 
+	if err := authorizeMerge(ctx, pr); err != nil {
+		return nil, err
+	}
+
 	if pr.Status == domain.PRStatusMerged {
 		return pr, nil
 	}
@@ -125,106 +503,212 @@ func (s *prService) Merge(ctx context.Context, id string) (*domain.PullRequest,
 	now := time.Now()
 	pr.Status = domain.PRStatusMerged
 	pr.MergedAt = &now
+	pr.MergedBy = actorID(ctx)
 
-	err = s.prRepo.Update(ctx, pr)
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return err
+		}
+		return s.prRepo.CreateEvent(ctx, &domain.PREvent{
+			PullRequestID: pr.ID,
+			Type:          domain.PREventMerged,
+			Actor:         pr.MergedBy,
+			CreatedAt:     now,
+		})
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	logger.FromContext(ctx).Info("pull request merged",
+		zap.String("pull_request_id", pr.ID),
+		zap.String("merged_by", pr.MergedBy),
+	)
+
 	return pr, nil
 }
 
-func (s *prService) Reassign(ctx context.Context, input ReassignReviewerInput) (*domain.PullRequest, string, error) {
+func (s *prService) Reassign(ctx context.Context, input ReassignReviewerInput) (*domain.PullRequest, *domain.AssignedReviewer, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Reassign")
+	defer span.End()
+
+	pr, err := s.prRepo.GetByID(ctx, input.PullRequestID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	if err := s.authorizePR(ctx, pr); err != nil {
+		return nil, nil, err
+	}
+
+	if pr.Status == domain.PRStatusMerged {
+		return nil, nil, domain.ErrPRMerged
+	}
+
+	return s.replaceReviewer(ctx, pr, input.ReviewerID, domain.DecisionKindReassign, domain.PREvent{Type: domain.PREventReassigned})
+}
+
+// Decline lets an assigned reviewer hand a review back. The reviewer is
+// replaced the same way as by Reassign and the reason is kept in the event.
+func (s *prService) Decline(ctx context.Context, input DeclineReviewInput) (*domain.PullRequest, *domain.AssignedReviewer, error) {
+	ctx, span := tracer.Start(ctx, "PRService.Decline")
+	defer span.End()
+
+	if err := authorizeDecline(ctx, input.ReviewerID); err != nil {
+		return nil, nil, err
+	}
+
 	pr, err := s.prRepo.GetByID(ctx, input.PullRequestID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return nil, "", domain.ErrNotFound
+			return nil, nil, domain.ErrNotFound
 		}
-		return nil, "", err
+		return nil, nil, err
 	}
 
 	if pr.Status == domain.PRStatusMerged {
-		return nil, "", domain.ErrPRMerged
+		return nil, nil, domain.ErrPRMerged
 	}
 
+	return s.replaceReviewer(ctx, pr, input.ReviewerID, domain.DecisionKindDecline, domain.PREvent{
+		Type:   domain.PREventDeclined,
+		Reason: input.Reason,
+	})
+}
+
+// replaceReviewer swaps reviewerID on pr for another member of their team or
+// a partner team, and records event and the decision of the given kind.
+func (s *prService) replaceReviewer(ctx context.Context, pr *domain.PullRequest, reviewerID string, kind domain.DecisionKind, event domain.PREvent) (*domain.PullRequest, *domain.AssignedReviewer, error) {
 	foundIndex := -1
 	for i, r := range pr.Reviewers {
-		if r == input.ReviewerID {
+		if r == reviewerID {
 			foundIndex = i
 			break
 		}
 	}
 
 	if foundIndex == -1 {
-		return nil, "", domain.ErrNotAssigned
+		return nil, nil, domain.ErrNotAssigned
 	}
 
-	oldReviewer, err := s.userRepo.GetByID(ctx, input.ReviewerID)
+	oldReviewer, err := s.userRepo.GetByID(ctx, reviewerID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
-			return nil, "", domain.ErrNotFound
+			return nil, nil, domain.ErrNotFound
 		}
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	candidates, err := s.userRepo.ListActiveByTeam(ctx, oldReviewer.TeamName)
+	decision := newDecisionRecorder(kind, newSeed())
+	ctx = withDecision(ctx, decision)
+
+	newReviewer, err := s.selector.pickReplacement(ctx, pr, oldReviewer)
 	if err != nil {
-		return nil, "", err
+		return nil, nil, err
 	}
 
-	filtered := make([]*domain.User, 0, len(candidates))
-	for _, u := range candidates {
-		if u.ID == input.ReviewerID {
-			continue
-		}
-		if u.ID == pr.AuthorID {
-			continue
-		}
-		alreadyReviewed := false
-		for _, r := range pr.Reviewers {
-			if r == u.ID {
-				alreadyReviewed = true
-				break
-			}
+	pr.Reviewers[foundIndex] = newReviewer.ID
+
+	now := time.Now()
+	event.PullRequestID = pr.ID
+	event.Actor = actorID(ctx)
+	event.OldReviewerID = reviewerID
+	event.NewReviewerID = newReviewer.ID
+	event.CreatedAt = now
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return err
 		}
-		if alreadyReviewed {
-			continue
+		if err := s.prRepo.CreateEvent(ctx, &event); err != nil {
+			return err
 		}
-		filtered = append(filtered, u)
+		return s.prRepo.CreateDecision(ctx, decision.finish(ctx, pr.ID, []string{newReviewer.ID}, now))
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if len(filtered) == 0 {
-		return nil, "", domain.ErrNoCandidate
+	logger.FromContext(ctx).Info("reviewer reassigned",
+		zap.String("pull_request_id", pr.ID),
+		zap.String("old_reviewer_id", reviewerID),
+		zap.String("new_reviewer_id", newReviewer.ID),
+		zap.String("event_type", string(event.Type)),
+		zap.String("reason", string(event.Reason)),
+		zap.String("actor", actorID(ctx)),
+	)
+
+	assigned := &domain.AssignedReviewer{UserID: newReviewer.ID, Source: domain.ReviewerSourceTeam}
+	if newReviewer.TeamName != oldReviewer.TeamName {
+		assigned.Source = domain.ReviewerSourcePartner
+		assigned.TeamName = newReviewer.TeamName
 	}
 
-	rand.Shuffle(len(filtered), func(i, j int) {
-		filtered[i], filtered[j] = filtered[j], filtered[i]
-	})
-	newReviewer := filtered[0]
+	return pr, assigned, nil
+}
 
-	pr.Reviewers[foundIndex] = newReviewer.ID
+func (s *prService) ListByReviewer(ctx context.Context, reviewerID string, input ListPRsInput) (*domain.PRPage, error) {
+	ctx, span := tracer.Start(ctx, "PRService.ListByReviewer")
+	defer span.End()
 
-	err = s.prRepo.Update(ctx, pr)
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	_, err := s.userRepo.GetByID(ctx, reviewerID)
 	if err != nil {
-		return nil, "", err
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
 	}
 
-	return pr, newReviewer.ID, nil
+	return s.listPage(ctx, domain.PRListFilter{ReviewerID: reviewerID}, input)
 }
 
-func (s *prService) ListByReviewer(ctx context.Context, reviewerID string) ([]domain.PullRequestShort, error) {
-	_, err := s.userRepo.GetByID(ctx, reviewerID)
-	if err != nil {
+func (s *prService) List(ctx context.Context, input ListPRsInput) (*domain.PRPage, error) {
+	ctx, span := tracer.Start(ctx, "PRService.List")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	return s.listPage(ctx, domain.PRListFilter{}, input)
+}
+
+// ListDecisions returns how the reviewers of the pull request were chosen,
+// one decision per automatic assignment.
+func (s *prService) ListDecisions(ctx context.Context, id string) ([]domain.AssignmentDecision, error) {
+	ctx, span := tracer.Start(ctx, "PRService.ListDecisions")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.prRepo.GetByID(ctx, id); err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			return nil, domain.ErrNotFound
 		}
 		return nil, err
 	}
 
-	prs, err := s.prRepo.ListByReviewer(ctx, reviewerID)
+	return s.prRepo.ListDecisions(ctx, id)
+}
+
+// authorizePR checks that the caller may manage pr, which belongs to the team of its author.
+func (s *prService) authorizePR(ctx context.Context, pr *domain.PullRequest) error {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
 	if err != nil {
-		return nil, err
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return err
 	}
 
-	return prs, nil
+	return authorize(ctx, actionManagePRs, author.TeamName)
 }
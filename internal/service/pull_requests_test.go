@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+type fakeTxKey struct{}
+
+// fakeTx marks the context so that fakes can check which kind of
+// transaction they run in.
+type fakeTx struct{}
+
+func (fakeTx) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, fakeTxKey{}, "tx"))
+}
+
+func (fakeTx) WithinSnapshot(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, fakeTxKey{}, "snapshot"))
+}
+
+func fakeTxKind(ctx context.Context) string {
+	kind, _ := ctx.Value(fakeTxKey{}).(string)
+	return kind
+}
+
+func inFakeTx(ctx context.Context) bool {
+	return fakeTxKind(ctx) == "tx"
+}
+
+// fakeWritePRRepo records which writes ran inside a transaction.
+type fakeWritePRRepo struct {
+	repository.PRRepository
+	prs       map[string]*domain.PullRequest
+	eventErr  error
+	writes    []string
+	outsideTx []string
+}
+
+func (f *fakeWritePRRepo) record(ctx context.Context, name string) {
+	f.writes = append(f.writes, name)
+	if !inFakeTx(ctx) {
+		f.outsideTx = append(f.outsideTx, name)
+	}
+}
+
+func (f *fakeWritePRRepo) GetByID(ctx context.Context, id string) (*domain.PullRequest, error) {
+	pr, ok := f.prs[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	c := *pr
+	return &c, nil
+}
+
+func (f *fakeWritePRRepo) Create(ctx context.Context, pr *domain.PullRequest) error {
+	f.record(ctx, "create")
+	return nil
+}
+
+func (f *fakeWritePRRepo) Update(ctx context.Context, pr *domain.PullRequest) error {
+	f.record(ctx, "update")
+	return nil
+}
+
+func (f *fakeWritePRRepo) CreateEvent(ctx context.Context, event *domain.PREvent) error {
+	f.record(ctx, "event")
+	return f.eventErr
+}
+
+func (f *fakeWritePRRepo) CreateDecision(ctx context.Context, decision *domain.AssignmentDecision) error {
+	f.record(ctx, "decision")
+	return nil
+}
+
+func TestMergeWritesEventInTransaction(t *testing.T) {
+	prs := &fakeWritePRRepo{
+		prs:      map[string]*domain.PullRequest{"pr-1": {ID: "pr-1", AuthorID: "u1", Status: domain.PRStatusOpen}},
+		eventErr: errors.New("insert failed"),
+	}
+	svc := NewPRService(prs, nil, nil, nil, nil, nil, fakeTx{})
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	_, err := svc.Merge(ctx, "pr-1")
+
+	require.Error(t, err)
+	assert.Equal(t, []string{"update", "event"}, prs.writes)
+	assert.Empty(t, prs.outsideTx)
+}
+
+func TestCreateWritesDecisionInTransaction(t *testing.T) {
+	sel := newTestSelector()
+	prs := &fakeWritePRRepo{PRRepository: sel.prRepo}
+	svc := NewPRService(prs, sel.userRepo, sel.teamRepo, sel.unavailabilityRepo, nil, &fakeReviewRulesRepo{rules: &domain.ReviewRules{}}, fakeTx{})
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	pr, _, err := svc.Create(ctx, CreatePRInput{ID: "pr-1", Name: "Add search", Author: "author"})
+
+	require.NoError(t, err)
+	assert.Len(t, pr.Reviewers, 2)
+	assert.Equal(t, []string{"create", "decision"}, prs.writes)
+	assert.Empty(t, prs.outsideTx)
+}
+
+func TestGetIncludesShadowReviewers(t *testing.T) {
+	prs := &fakeWritePRRepo{prs: map[string]*domain.PullRequest{"pr-1": {
+		ID:              "pr-1",
+		AuthorID:        "author",
+		Status:          domain.PRStatusOpen,
+		Reviewers:       []string{"u0"},
+		ShadowReviewers: []string{"j1"},
+	}}}
+	users := &fakeUserRepo{users: []*domain.User{
+		{ID: "author", Username: "Alice", TeamName: "backend", IsActive: true},
+		{ID: "u0", Username: "Bob", TeamName: "backend", IsActive: true},
+		{ID: "j1", Username: "Jim", TeamName: "backend", IsActive: true},
+	}}
+	svc := NewPRService(prs, users, nil, nil, nil, nil, fakeTx{})
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleReadOnly})
+
+	details, err := svc.Get(ctx, "pr-1")
+
+	require.NoError(t, err)
+	require.Len(t, details.ReviewerDetails, 2)
+	assert.Equal(t, "Bob", details.ReviewerDetails[0].Username)
+	assert.Equal(t, domain.ReviewerRoleReviewer, details.ReviewerDetails[0].Role)
+	assert.Equal(t, "Jim", details.ReviewerDetails[1].Username)
+	assert.Equal(t, domain.ReviewerRoleShadow, details.ReviewerDetails[1].Role)
+}
+
+type fakeListPRRepo struct {
+	repository.PRRepository
+	filters []domain.PRListFilter
+}
+
+func (f *fakeListPRRepo) List(ctx context.Context, filter domain.PRListFilter) ([]domain.PullRequestShort, error) {
+	f.filters = append(f.filters, filter)
+	return []domain.PullRequestShort{}, nil
+}
+
+func TestListByReviewerUnpagedOnlyWithoutLimitOrCursor(t *testing.T) {
+	prs := &fakeListPRRepo{}
+	users := &fakeUserRepo{users: []*domain.User{{ID: "u2", TeamName: "backend", IsActive: true}}}
+	svc := NewPRService(prs, users, nil, nil, nil, nil, fakeTx{})
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleReadOnly})
+
+	for _, input := range []ListPRsInput{
+		{Unpaged: true},
+		{Unpaged: true, Limit: 10},
+		{},
+	} {
+		_, err := svc.ListByReviewer(ctx, "u2", input)
+		require.NoError(t, err)
+	}
+
+	require.Len(t, prs.filters, 3)
+	assert.Zero(t, prs.filters[0].Limit)
+	assert.Equal(t, 11, prs.filters[1].Limit)
+	assert.Equal(t, DefaultPageSize+1, prs.filters[2].Limit)
+}
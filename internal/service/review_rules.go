@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/codeowners"
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// neverPaired returns the users that must not review pull requests of userID
+// nor have them reviewed by userID.
+func neverPaired(rules *domain.ReviewRules, userID string) []string {
+	var result []string
+	for _, p := range rules.NeverPair {
+		switch userID {
+		case p.AuthorID:
+			result = append(result, p.ReviewerID)
+		case p.ReviewerID:
+			result = append(result, p.AuthorID)
+		}
+	}
+	return result
+}
+
+// isMandatory reports whether userID is an always-include reviewer of rules.
+// Pull requests do not keep their changed files, so the paths of the rule are
+// not checked.
+func isMandatory(rules *domain.ReviewRules, userID string) bool {
+	return slices.ContainsFunc(rules.AlwaysInclude, func(m domain.MandatoryReviewer) bool {
+		return m.UserID == userID
+	})
+}
+
+// mandatoryReviewers returns the always-include reviewers whose rule applies
+// to files. They are assigned regardless of their review limits, but not when
+// they cannot review at all or are excluded.
+func (s *prService) mandatoryReviewers(ctx context.Context, rules *domain.ReviewRules, files []string, at time.Time, exclude ...string) ([]domain.AssignedReviewer, error) {
+	var ids []string
+	for _, m := range rules.AlwaysInclude {
+		ok, err := matchesAny(m.Paths, files)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ids = append(ids, m.UserID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	users, err := s.userRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	candidates, err := s.selector.eligible(ctx, users, at, exclude...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]domain.AssignedReviewer, 0, len(ids))
+	for _, id := range ids {
+		if !slices.ContainsFunc(candidates, func(u *domain.User) bool { return u.ID == id }) {
+			if !slices.Contains(exclude, id) {
+				logger.FromContext(ctx).Warn("mandatory reviewer is not available", zap.String("user_id", id))
+			}
+			continue
+		}
+		decisionFrom(ctx).candidate(id)
+		result = append(result, domain.AssignedReviewer{UserID: id, Source: domain.ReviewerSourceMandatory})
+	}
+
+	return result, nil
+}
+
+// matchesAny reports whether one of files matches one of patterns. No
+// patterns match every pull request.
+func matchesAny(patterns, files []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+
+	for _, pattern := range patterns {
+		p, err := codeowners.CompilePattern(pattern)
+		if err != nil {
+			return false, err
+		}
+		if slices.ContainsFunc(files, p.Match) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ensureSenior makes sure one of assigned is a senior if the rules require
+// it, taking one from the team otherwise. The first fixed reviewers are kept; among the others the one covering
+// the fewest tags is replaced. If no senior can review, assigned is returned
+// unchanged.
+func (s *prService) ensureSenior(ctx context.Context, rules *domain.ReviewRules, assigned []domain.AssignedReviewer, fixed int, at time.Time, exclude ...string) ([]domain.AssignedReviewer, error) {
+	if !rules.RequireSenior {
+		return assigned, nil
+	}
+
+	exclude = slices.Clone(exclude)
+	ids := make([]string, 0, len(assigned))
+	for _, a := range assigned {
+		ids = append(ids, a.UserID)
+	}
+	hasSenior, err := s.selector.anySenior(ctx, ids)
+	if err != nil || hasSenior {
+		return assigned, err
+	}
+	exclude = append(exclude, ids...)
+
+	seniors, err := s.selector.seniorsOf(ctx, rules.TeamName)
+	if err != nil {
+		return nil, err
+	}
+	picked, err := s.selector.pickFrom(ctx, seniors, at, 1, exclude...)
+	if err != nil {
+		return nil, err
+	}
+	if len(picked) == 0 {
+		logger.FromContext(ctx).Warn("no senior reviewer available", zap.String("team_name", rules.TeamName))
+		return assigned, nil
+	}
+
+	senior := domain.AssignedReviewer{UserID: picked[0].ID, Source: domain.ReviewerSourceSenior}
+
+	replace := -1
+	if len(assigned) >= reviewerCount {
+		for i := len(assigned) - 1; i >= fixed; i-- {
+			if replace == -1 || len(assigned[i].CoveredTags) < len(assigned[replace].CoveredTags) {
+				replace = i
+			}
+		}
+	}
+	if replace == -1 {
+		return append(assigned, senior), nil
+	}
+
+	assigned = slices.Clone(assigned)
+	assigned[replace] = senior
+	return assigned, nil
+}
+
+// validateReviewRules checks that every referenced user exists and has not
+// been offboarded, that both users of a never-pair belong to the team and that
+// paths are valid patterns. Problems are reported per entry, numbered from 1 within
+// their list.
+func validateReviewRules(ctx context.Context, userRepo repository.UserRepository, rules *domain.ReviewRules) ([]domain.ImportRowError, error) {
+	var ids []string
+	for _, m := range rules.AlwaysInclude {
+		ids = append(ids, m.UserID)
+	}
+	for _, p := range rules.NeverPair {
+		ids = append(ids, p.AuthorID, p.ReviewerID)
+	}
+
+	users, err := userRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]*domain.User, len(users))
+	for _, u := range users {
+		if !u.Deleted() {
+			byID[u.ID] = u
+		}
+	}
+
+	var rowErrs []domain.ImportRowError
+	rowErr := func(row int, field, msg string) {
+		rowErrs = append(rowErrs, domain.ImportRowError{Row: row, Field: field, Message: msg})
+	}
+	checkUser := func(row int, field, id string) {
+		if id == "" {
+			rowErr(row, field, "is required")
+		} else if byID[id] == nil {
+			rowErr(row, field, fmt.Sprintf("unknown user %q", id))
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, m := range rules.AlwaysInclude {
+		checkUser(i+1, "always_include.user_id", m.UserID)
+		if seen[m.UserID] {
+			rowErr(i+1, "always_include.user_id", "user is listed more than once")
+		}
+		seen[m.UserID] = true
+
+		for _, pattern := range m.Paths {
+			if _, err := codeowners.CompilePattern(pattern); err != nil {
+				rowErr(i+1, "always_include.paths", err.Error())
+			}
+		}
+	}
+
+	pairs := make(map[domain.NeverPair]bool)
+	for i, p := range rules.NeverPair {
+		checkUser(i+1, "never_pair.author_id", p.AuthorID)
+		checkUser(i+1, "never_pair.reviewer_id", p.ReviewerID)
+		if author := byID[p.AuthorID]; author != nil && author.TeamName != rules.TeamName {
+			rowErr(i+1, "never_pair.author_id", "author is not a member of the team")
+		}
+		if reviewer := byID[p.ReviewerID]; reviewer != nil && reviewer.TeamName != rules.TeamName {
+			rowErr(i+1, "never_pair.reviewer_id", "reviewer is not a member of the team")
+		}
+		if p.AuthorID != "" && p.AuthorID == p.ReviewerID {
+			rowErr(i+1, "never_pair.reviewer_id", "must differ from author_id")
+		}
+		reversed := domain.NeverPair{AuthorID: p.ReviewerID, ReviewerID: p.AuthorID}
+		if pairs[p] || pairs[reversed] {
+			rowErr(i+1, "never_pair", "pair is listed more than once")
+		}
+		pairs[p] = true
+	}
+
+	return rowErrs, nil
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+type fakeReviewRulesRepo struct {
+	repository.ReviewRulesRepository
+	rules *domain.ReviewRules
+}
+
+func (f *fakeReviewRulesRepo) Get(ctx context.Context, teamName string) (*domain.ReviewRules, error) {
+	return f.rules, nil
+}
+
+func newRulesSelector(rules *domain.ReviewRules) *reviewerSelector {
+	s := newTestSelector()
+	s.reviewRulesRepo = &fakeReviewRulesRepo{rules: rules}
+	return s
+}
+
+func replace(t *testing.T, s *reviewerSelector, pr *domain.PullRequest, oldReviewer string) (*domain.User, error) {
+	t.Helper()
+
+	old, err := s.userRepo.GetByID(context.Background(), oldReviewer)
+	require.NoError(t, err)
+
+	ctx := withDecision(context.Background(), newDecisionRecorder(domain.DecisionKindReassign, 1))
+	return s.pickReplacement(ctx, pr, old)
+}
+
+func TestNeverPairedIsSymmetric(t *testing.T) {
+	rules := &domain.ReviewRules{NeverPair: []domain.NeverPair{
+		{AuthorID: "u0", ReviewerID: "u1"},
+		{AuthorID: "u2", ReviewerID: "u0"},
+	}}
+
+	assert.ElementsMatch(t, []string{"u1", "u2"}, neverPaired(rules, "u0"))
+	assert.Equal(t, []string{"u0"}, neverPaired(rules, "u1"))
+	assert.Empty(t, neverPaired(rules, "u3"))
+}
+
+func TestPickReplacementSkipsReviewerNeverPairedWithAuthor(t *testing.T) {
+	// The pair lists the author as reviewer: it must hold in both directions.
+	var pairs []domain.NeverPair
+	for _, id := range []string{"u1", "u2", "u3", "u4", "u5", "u6"} {
+		pairs = append(pairs, domain.NeverPair{AuthorID: id, ReviewerID: "author"})
+	}
+	s := newRulesSelector(&domain.ReviewRules{NeverPair: pairs})
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "author", Reviewers: []string{"u0"}}
+
+	for range 10 {
+		picked, err := replace(t, s, pr, "u0")
+		require.NoError(t, err)
+		assert.Equal(t, "u7", picked.ID)
+	}
+}
+
+func TestPickReplacementKeepsMandatoryReviewer(t *testing.T) {
+	rules := &domain.ReviewRules{AlwaysInclude: []domain.MandatoryReviewer{
+		{UserID: "u0"},
+		{UserID: "u1", Paths: []string{"/security/"}},
+	}}
+	s := newRulesSelector(rules)
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "author", Reviewers: []string{"u0", "u2"}}
+
+	picked, err := replace(t, s, pr, "u0")
+	require.NoError(t, err)
+	assert.Equal(t, "u1", picked.ID)
+
+	pr.Reviewers = []string{"u0", "u1"}
+	_, err = replace(t, s, pr, "u0")
+	assert.ErrorIs(t, err, domain.ErrMandatoryReviewer)
+}
+
+func TestPickReplacementKeepsTeamSenior(t *testing.T) {
+	s := newRulesSelector(&domain.ReviewRules{RequireSenior: true})
+	for _, u := range s.userRepo.(*fakeUserRepo).users {
+		if u.ID == "u0" || u.ID == "u5" {
+			u.Seniority = domain.SenioritySenior
+		}
+	}
+	pr := &domain.PullRequest{ID: "pr-1", AuthorID: "author", Reviewers: []string{"u0", "u1"}}
+
+	for range 10 {
+		picked, err := replace(t, s, pr, "u0")
+		require.NoError(t, err)
+		assert.Equal(t, "u5", picked.ID)
+	}
+}
+
+// newManualReviewersService serves pr-1 reviewed by u0 and u1, pr-2 reviewed
+// by u2 and u3 and pr-3 reviewed by u4 alone; seniors are given SenioritySenior.
+func newManualReviewersService(rules *domain.ReviewRules, seniors ...string) (PRService, *fakeWritePRRepo) {
+	sel := newTestSelector()
+	for _, u := range sel.userRepo.(*fakeUserRepo).users {
+		if slices.Contains(seniors, u.ID) {
+			u.Seniority = domain.SenioritySenior
+		}
+	}
+	prs := &fakeWritePRRepo{PRRepository: sel.prRepo, prs: map[string]*domain.PullRequest{
+		"pr-1": {ID: "pr-1", AuthorID: "author", Status: domain.PRStatusOpen, Reviewers: []string{"u0", "u1"}},
+		"pr-2": {ID: "pr-2", AuthorID: "author", Status: domain.PRStatusOpen, Reviewers: []string{"u2", "u3"}},
+		"pr-3": {ID: "pr-3", AuthorID: "author", Status: domain.PRStatusOpen, Reviewers: []string{"u4"}},
+	}}
+	svc := NewPRService(prs, sel.userRepo, sel.teamRepo, sel.unavailabilityRepo, nil, &fakeReviewRulesRepo{rules: rules}, fakeTx{})
+	return svc, prs
+}
+
+func TestManualReviewerChangesFollowReviewRules(t *testing.T) {
+	rules := &domain.ReviewRules{
+		AlwaysInclude: []domain.MandatoryReviewer{{UserID: "u0"}, {UserID: "u2"}},
+		NeverPair:     []domain.NeverPair{{AuthorID: "u4", ReviewerID: "author"}},
+	}
+	svc, prs := newManualReviewersService(rules)
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	_, err := svc.AddReviewer(ctx, ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u4"})
+	assert.ErrorIs(t, err, domain.ErrNeverPaired)
+
+	_, err = svc.RemoveReviewer(ctx, ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u0"})
+	assert.ErrorIs(t, err, domain.ErrMandatoryReviewer)
+
+	_, _, err = svc.SwapReviewers(ctx, SwapReviewersInput{
+		First:  ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u0"},
+		Second: ReviewerChangeInput{PullRequestID: "pr-2", ReviewerID: "u3"},
+	})
+	assert.ErrorIs(t, err, domain.ErrMandatoryReviewer)
+	assert.Empty(t, prs.writes)
+
+	first, second, err := svc.SwapReviewers(ctx, SwapReviewersInput{
+		First:  ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u0"},
+		Second: ReviewerChangeInput{PullRequestID: "pr-2", ReviewerID: "u2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u1"}, first.Reviewers)
+	assert.Equal(t, []string{"u0", "u3"}, second.Reviewers)
+}
+
+func TestRemoveReviewerKeepsLastReviewerAndSenior(t *testing.T) {
+	svc, prs := newManualReviewersService(&domain.ReviewRules{RequireSenior: true}, "u0")
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	_, err := svc.RemoveReviewer(ctx, ReviewerChangeInput{PullRequestID: "pr-3", ReviewerID: "u4"})
+	assert.ErrorIs(t, err, domain.ErrLastReviewer)
+
+	_, err = svc.RemoveReviewer(ctx, ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u0"})
+	assert.ErrorIs(t, err, domain.ErrSeniorRequired)
+	assert.Empty(t, prs.writes)
+
+	pr, err := svc.RemoveReviewer(ctx, ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u0"}, pr.Reviewers)
+}
+
+func TestSwapReviewersKeepsSenior(t *testing.T) {
+	svc, prs := newManualReviewersService(&domain.ReviewRules{RequireSenior: true}, "u0", "u2")
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	_, _, err := svc.SwapReviewers(ctx, SwapReviewersInput{
+		First:  ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u0"},
+		Second: ReviewerChangeInput{PullRequestID: "pr-2", ReviewerID: "u3"},
+	})
+	assert.ErrorIs(t, err, domain.ErrSeniorRequired)
+	assert.Empty(t, prs.writes)
+
+	first, second, err := svc.SwapReviewers(ctx, SwapReviewersInput{
+		First:  ReviewerChangeInput{PullRequestID: "pr-1", ReviewerID: "u0"},
+		Second: ReviewerChangeInput{PullRequestID: "pr-2", ReviewerID: "u2"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"u2", "u1"}, first.Reviewers)
+	assert.Equal(t, []string{"u0", "u3"}, second.Reviewers)
+}
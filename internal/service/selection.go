@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+// reviewerSelector decides who may be assigned as a reviewer. It is shared by
+// pull request creation, reassignment and user offboarding.
+type reviewerSelector struct {
+	userRepo           repository.UserRepository
+	teamRepo           repository.TeamRepository
+	prRepo             repository.PRRepository
+	unavailabilityRepo repository.UnavailabilityRepository
+	reviewRulesRepo    repository.ReviewRulesRepository
+}
+
+func newReviewerSelector(userRepo repository.UserRepository, teamRepo repository.TeamRepository, prRepo repository.PRRepository, unavailabilityRepo repository.UnavailabilityRepository, reviewRulesRepo repository.ReviewRulesRepository) *reviewerSelector {
+	return &reviewerSelector{
+		userRepo:           userRepo,
+		teamRepo:           teamRepo,
+		prRepo:             prRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		reviewRulesRepo:    reviewRulesRepo,
+	}
+}
+
+// eligible drops the users that cannot review at time at: inactive,
+// offboarded or out of office ones and those listed in exclude. Dropped users
+// are recorded in the decision of ctx.
+func (s *reviewerSelector) eligible(ctx context.Context, users []*domain.User, at time.Time, exclude ...string) ([]*domain.User, error) {
+	ids := make([]string, 0, len(users))
+	for _, u := range users {
+		ids = append(ids, u.ID)
+	}
+	unavailable, err := s.unavailabilityRepo.UnavailableAt(ctx, ids, at)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := decisionFrom(ctx)
+	result := make([]*domain.User, 0, len(users))
+	for _, u := range users {
+		switch {
+		case slices.Contains(exclude, u.ID):
+			decision.exclude(u.ID, decision.reasonFor(u.ID))
+		case u.Deleted():
+			decision.exclude(u.ID, domain.ExclusionOffboarded)
+		case !u.IsActive:
+			decision.exclude(u.ID, domain.ExclusionInactive)
+		case unavailable[u.ID]:
+			decision.exclude(u.ID, domain.ExclusionUnavailable)
+		default:
+			result = append(result, u)
+		}
+	}
+
+	return result, nil
+}
+
+// shuffleByCapacity shuffles candidates with the seeded source of the decision
+// of ctx and splits off those below their max_open_reviews. load holds the
+// open review count of every candidate.
+func (s *reviewerSelector) shuffleByCapacity(ctx context.Context, candidates []*domain.User) (free []*domain.User, load map[string]int, err error) {
+	ids := make([]string, 0, len(candidates))
+	for _, u := range candidates {
+		ids = append(ids, u.ID)
+	}
+	load, err = s.prRepo.CountOpenReviews(ctx, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decisionFrom(ctx).shuffle(candidates)
+
+	free = make([]*domain.User, 0, len(candidates))
+	for _, u := range candidates {
+		if u.MaxOpenReviews == nil || load[u.ID] < *u.MaxOpenReviews {
+			free = append(free, u)
+		}
+	}
+
+	return free, load, nil
+}
+
+// pick chooses up to n random reviewers from teamName. Candidates who reached
+// their max_open_reviews are skipped; if that leaves nobody, the team's
+// capacity policy either rejects the assignment with domain.ErrAllAtCapacity
+// or falls back to the least loaded candidates. An empty result means the team
+// has no candidates at all.
+func (s *reviewerSelector) pick(ctx context.Context, teamName string, at time.Time, n int, exclude ...string) ([]*domain.User, error) {
+	users, err := s.userRepo.ListActiveByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.eligible(ctx, users, at, exclude...)
+	if err != nil || len(candidates) == 0 {
+		return candidates, err
+	}
+
+	free, load, err := s.shuffleByCapacity(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	decision := decisionFrom(ctx)
+
+	if len(free) == 0 {
+		team, err := s.teamRepo.GetByName(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		if team.CapacityPolicy != domain.CapacityPolicyLeastLoaded {
+			for _, u := range candidates {
+				decision.exclude(u.ID, domain.ExclusionAtCapacity)
+			}
+			return nil, domain.ErrAllAtCapacity
+		}
+
+		free = candidates
+		slices.SortStableFunc(free, func(a, b *domain.User) int {
+			return load[a.ID] - load[b.ID]
+		})
+		decision.useStrategy(domain.DecisionStrategyLeastLoaded)
+	}
+
+	recordCandidates(decision, candidates, free)
+
+	return free[:min(n, len(free))], nil
+}
+
+// pickFrom chooses up to n random reviewers among users, skipping those who
+// cannot review or reached their max_open_reviews. Unlike pick it never falls
+// back, since the caller still has the team pool to draw from.
+func (s *reviewerSelector) pickFrom(ctx context.Context, users []*domain.User, at time.Time, n int, exclude ...string) ([]*domain.User, error) {
+	candidates, err := s.eligible(ctx, users, at, exclude...)
+	if err != nil || len(candidates) == 0 {
+		return candidates, err
+	}
+
+	free, _, err := s.shuffleByCapacity(ctx, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	recordCandidates(decisionFrom(ctx), candidates, free)
+
+	return free[:min(n, len(free))], nil
+}
+
+// recordCandidates records free as candidates and the rest of all as at capacity.
+func recordCandidates(decision *decisionRecorder, all, free []*domain.User) {
+	for _, u := range all {
+		if slices.Contains(free, u) {
+			decision.candidate(u.ID)
+		} else {
+			decision.exclude(u.ID, domain.ExclusionAtCapacity)
+		}
+	}
+}
+
+// pickPartners chooses up to n reviewers from the partner teams of teamName,
+// taking as many as possible from each partner before moving to the next one.
+// Partners whose candidates are all at capacity are skipped.
+func (s *reviewerSelector) pickPartners(ctx context.Context, teamName string, at time.Time, n int, exclude ...string) ([]*domain.User, error) {
+	team, err := s.teamRepo.GetByName(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude = slices.Clone(exclude)
+	result := make([]*domain.User, 0, n)
+
+	for _, partner := range team.PartnerTeams {
+		if len(result) == n {
+			break
+		}
+
+		picked, err := s.pick(ctx, partner, at, n-len(result), exclude...)
+		if errors.Is(err, domain.ErrAllAtCapacity) || errors.Is(err, repository.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, u := range picked {
+			result = append(result, u)
+			exclude = append(exclude, u.ID)
+		}
+	}
+
+	return result, nil
+}
+
+// pickShadow chooses a random junior of teamName to shadow a review. Shadow
+// reviews do not count towards review limits, so capacity is not checked. It
+// returns nil if the team has no junior who can review.
+func (s *reviewerSelector) pickShadow(ctx context.Context, teamName string, at time.Time, exclude ...string) (*domain.User, error) {
+	users, err := s.userRepo.ListActiveByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.eligible(ctx, users, at, exclude...)
+	if err != nil {
+		return nil, err
+	}
+	candidates = slices.DeleteFunc(candidates, func(u *domain.User) bool {
+		return u.Seniority != domain.SeniorityJunior
+	})
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	return candidates[decisionFrom(ctx).rng.Intn(len(candidates))], nil
+}
+
+// seniorsOf returns the active members of teamName of SenioritySenior.
+func (s *reviewerSelector) seniorsOf(ctx context.Context, teamName string) ([]*domain.User, error) {
+	users, err := s.userRepo.ListActiveByTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(users, func(u *domain.User) bool {
+		return u.Seniority != domain.SenioritySenior
+	}), nil
+}
+
+// anySenior reports whether one of ids is a user of SenioritySenior.
+func (s *reviewerSelector) anySenior(ctx context.Context, ids []string) (bool, error) {
+	if len(ids) == 0 {
+		return false, nil
+	}
+	users, err := s.userRepo.ListByIDs(ctx, ids)
+	if err != nil {
+		return false, err
+	}
+	return slices.ContainsFunc(users, func(u *domain.User) bool {
+		return u.Seniority == domain.SenioritySenior
+	}), nil
+}
+
+// pickReplacement chooses a teammate of oldReviewer who is neither the
+// author of pr nor already reviewing it. If the team has nobody left, the
+// replacement is taken from its partner teams. The review rules of the
+// author's team apply: never-paired reviewers are skipped, a mandatory
+// reviewer is only replaced by another mandatory one, failing with
+// domain.ErrMandatoryReviewer otherwise, and a senior is preferred when
+// oldReviewer was the only one.
+func (s *reviewerSelector) pickReplacement(ctx context.Context, pr *domain.PullRequest, oldReviewer *domain.User) (*domain.User, error) {
+	author, err := s.userRepo.GetByID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+	rules, err := s.reviewRulesRepo.Get(ctx, author.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude := slices.Concat([]string{oldReviewer.ID, pr.AuthorID}, pr.Reviewers, pr.ShadowReviewers)
+	exclude = append(exclude, neverPaired(rules, pr.AuthorID)...)
+
+	decision := decisionFrom(ctx)
+	decision.excludeAs(domain.ExclusionAuthor, pr.AuthorID)
+	decision.excludeAs(domain.ExclusionNeverPair, neverPaired(rules, pr.AuthorID)...)
+	at := time.Now()
+
+	if isMandatory(rules, oldReviewer.ID) {
+		ids := make([]string, 0, len(rules.AlwaysInclude))
+		for _, m := range rules.AlwaysInclude {
+			ids = append(ids, m.UserID)
+		}
+		mandatory, err := s.userRepo.ListByIDs(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		picked, err := s.pickFrom(ctx, mandatory, at, 1, exclude...)
+		if err != nil {
+			return nil, err
+		}
+		if len(picked) == 0 {
+			return nil, domain.ErrMandatoryReviewer
+		}
+		return picked[0], nil
+	}
+
+	if rules.RequireSenior && oldReviewer.Seniority == domain.SenioritySenior {
+		others := slices.DeleteFunc(slices.Clone(pr.Reviewers), func(id string) bool { return id == oldReviewer.ID })
+		hasSenior, err := s.anySenior(ctx, others)
+		if err != nil {
+			return nil, err
+		}
+		if !hasSenior {
+			seniors, err := s.seniorsOf(ctx, author.TeamName)
+			if err != nil {
+				return nil, err
+			}
+			picked, err := s.pickFrom(ctx, seniors, at, 1, exclude...)
+			if err != nil {
+				return nil, err
+			}
+			if len(picked) > 0 {
+				return picked[0], nil
+			}
+		}
+	}
+
+	picked, err := s.pick(ctx, oldReviewer.TeamName, at, 1, exclude...)
+	if err != nil && !errors.Is(err, domain.ErrAllAtCapacity) {
+		return nil, err
+	}
+	if len(picked) > 0 {
+		return picked[0], nil
+	}
+
+	partners, perr := s.pickPartners(ctx, oldReviewer.TeamName, at, 1, exclude...)
+	if perr != nil {
+		return nil, perr
+	}
+	if len(partners) > 0 {
+		return partners[0], nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	return nil, domain.ErrNoCandidate
+}
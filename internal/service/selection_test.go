@@ -0,0 +1,167 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+type fakeUserRepo struct {
+	repository.UserRepository
+	users []*domain.User
+	tags  map[string][]string
+}
+
+func (f *fakeUserRepo) ListActiveByTeam(ctx context.Context, teamName string) ([]*domain.User, error) {
+	var result []*domain.User
+	for _, u := range f.users {
+		if u.TeamName == teamName {
+			c := *u
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeUserRepo) GetByID(ctx context.Context, id string) (*domain.User, error) {
+	for _, u := range f.users {
+		if u.ID == id {
+			c := *u
+			return &c, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+func (f *fakeUserRepo) ListByIDs(ctx context.Context, ids []string) ([]*domain.User, error) {
+	var result []*domain.User
+	for _, u := range f.users {
+		if slices.Contains(ids, u.ID) {
+			c := *u
+			result = append(result, &c)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeUserRepo) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	var result []domain.Tag
+	for _, tags := range f.tags {
+		for _, tag := range tags {
+			result = append(result, domain.Tag{Name: tag})
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeUserRepo) TagsByUsers(ctx context.Context, userIDs []string) (map[string][]string, error) {
+	result := make(map[string][]string)
+	for _, id := range userIDs {
+		if tags, ok := f.tags[id]; ok {
+			result[id] = tags
+		}
+	}
+	return result, nil
+}
+
+// fakeTeamRepo returns teams by name; unknown teams reject reviewers at capacity.
+type fakeTeamRepo struct {
+	repository.TeamRepository
+	teams map[string]*domain.Team
+}
+
+func (f *fakeTeamRepo) GetByName(ctx context.Context, name string) (*domain.Team, error) {
+	if team, ok := f.teams[name]; ok {
+		c := *team
+		return &c, nil
+	}
+	return &domain.Team{Name: name, CapacityPolicy: domain.CapacityPolicyReject}, nil
+}
+
+type fakePRRepo struct {
+	repository.PRRepository
+	load map[string]int
+}
+
+func (f *fakePRRepo) CountOpenReviews(ctx context.Context, userIDs []string) (map[string]int, error) {
+	return f.load, nil
+}
+
+type fakeUnavailabilityRepo struct {
+	repository.UnavailabilityRepository
+	unavailable map[string]bool
+}
+
+func (f *fakeUnavailabilityRepo) UnavailableAt(ctx context.Context, userIDs []string, at time.Time) (map[string]bool, error) {
+	return f.unavailable, nil
+}
+
+func newTestSelector() *reviewerSelector {
+	limit := 1
+	users := []*domain.User{
+		{ID: "author", TeamName: "backend", IsActive: true},
+		{ID: "idle", TeamName: "backend", IsActive: false},
+		{ID: "away", TeamName: "backend", IsActive: true},
+		{ID: "busy", TeamName: "backend", IsActive: true, MaxOpenReviews: &limit},
+	}
+	for i := range 8 {
+		users = append(users, &domain.User{ID: fmt.Sprintf("u%d", i), TeamName: "backend", IsActive: true})
+	}
+
+	return newReviewerSelector(
+		&fakeUserRepo{users: users},
+		&fakeTeamRepo{},
+		&fakePRRepo{load: map[string]int{"busy": 1}},
+		&fakeUnavailabilityRepo{unavailable: map[string]bool{"away": true}},
+		nil,
+	)
+}
+
+func pickWithSeed(t *testing.T, seed int64) ([]string, *domain.AssignmentDecision) {
+	t.Helper()
+
+	decision := newDecisionRecorder(domain.DecisionKindCreate, seed)
+	decision.excludeAs(domain.ExclusionAuthor, "author")
+	ctx := withDecision(context.Background(), decision)
+
+	picked, err := newTestSelector().pick(ctx, "backend", time.Now(), 2, "author")
+	require.NoError(t, err)
+
+	ids := make([]string, 0, len(picked))
+	for _, u := range picked {
+		ids = append(ids, u.ID)
+	}
+	return ids, decision.finish(ctx, "pr-1", ids, time.Time{})
+}
+
+func TestPickReplaysWithSameSeed(t *testing.T) {
+	first, firstDecision := pickWithSeed(t, 42)
+	second, secondDecision := pickWithSeed(t, 42)
+
+	assert.Len(t, first, 2)
+	assert.Equal(t, first, second)
+	assert.Equal(t, firstDecision, secondDecision)
+}
+
+func TestPickRecordsExclusions(t *testing.T) {
+	_, decision := pickWithSeed(t, 7)
+
+	assert.Equal(t, domain.DecisionStrategyRandom, decision.Strategy)
+	assert.Equal(t, int64(7), decision.Seed)
+	assert.ElementsMatch(t, []domain.Exclusion{
+		{UserID: "author", Reason: domain.ExclusionAuthor},
+		{UserID: "idle", Reason: domain.ExclusionInactive},
+		{UserID: "away", Reason: domain.ExclusionUnavailable},
+		{UserID: "busy", Reason: domain.ExclusionAtCapacity},
+	}, decision.Exclusions)
+	assert.Len(t, decision.Candidates, 8)
+	assert.NotContains(t, decision.Candidates, "busy")
+}
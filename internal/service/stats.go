@@ -22,6 +22,13 @@ func NewStatsService(prRepo repository.PRRepository) StatsService {
 }
 
 func (s statsService) Get(ctx context.Context) (*domain.Stats, error) {
+	ctx, span := tracer.Start(ctx, "StatsService.Get")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
 	total, err := s.prRepo.CountAll(ctx)
 	if err != nil {
 		return nil, err
@@ -41,6 +48,11 @@ func (s statsService) Get(ctx context.Context) (*domain.Stats, error) {
 	if err != nil {
 		return nil, err
 	}
+	for i := range reviewers {
+		if given := reviewers[i].ReviewsCount + reviewers[i].DeclinedCount; given > 0 {
+			reviewers[i].DeclineRate = float64(reviewers[i].DeclinedCount) / float64(given)
+		}
+	}
 
 	stats := &domain.Stats{
 		TotalPR:        total,
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+type fakeStatsRepo struct {
+	repository.PRRepository
+	reviewers []domain.UserReviewStat
+}
+
+func (f *fakeStatsRepo) CountAll(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (f *fakeStatsRepo) CountByStatus(ctx context.Context, status domain.PRStatus) (int, error) {
+	return 0, nil
+}
+
+func (f *fakeStatsRepo) CountAssignmentsByReviewer(ctx context.Context) ([]domain.UserReviewStat, error) {
+	return f.reviewers, nil
+}
+
+func TestStatsDeclineRate(t *testing.T) {
+	svc := NewStatsService(&fakeStatsRepo{reviewers: []domain.UserReviewStat{
+		{UserID: "u1", ReviewsCount: 3, DeclinedCount: 1},
+		{UserID: "u2", DeclinedCount: 2},
+		{UserID: "u3", ReviewsCount: 4},
+	}})
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleMember})
+
+	stats, err := svc.Get(ctx)
+	require.NoError(t, err)
+
+	assert.InDelta(t, 0.25, stats.ReviewsPerUser[0].DeclineRate, 1e-9)
+	assert.InDelta(t, 1.0, stats.ReviewsPerUser[1].DeclineRate, 1e-9)
+	assert.Zero(t, stats.ReviewsPerUser[2].DeclineRate)
+}
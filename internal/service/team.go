@@ -3,14 +3,34 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
 
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/codeowners"
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+	"github.com/CodebyTecs/pr-assign-service/internal/teamimport"
 )
 
 type CreateTeamInput struct {
-	Name    string
-	Members []CreateTeamMemberInput
+	Name           string
+	CapacityPolicy domain.CapacityPolicy
+	Members        []CreateTeamMemberInput
+}
+
+// UpdateTeamInput changes team settings; nil fields are left as they are.
+// PartnerTeams replaces the ordered list of partner teams.
+type UpdateTeamInput struct {
+	Name           string
+	CapacityPolicy *domain.CapacityPolicy
+	PartnerTeams   *[]string
+	Mentoring      *bool
 }
 
 type CreateTeamMemberInput struct {
@@ -19,24 +39,49 @@ type CreateTeamMemberInput struct {
 	IsActive bool
 }
 
+type ImportTeamsInput struct {
+	Format teamimport.Format
+	Body   io.Reader
+	DryRun bool
+}
+
 type teamService struct {
-	userRepo repository.UserRepository
-	teamRepo repository.TeamRepository
+	userRepo        repository.UserRepository
+	teamRepo        repository.TeamRepository
+	codeownersRepo  repository.CodeownersRepository
+	reviewRulesRepo repository.ReviewRulesRepository
+	tx              repository.Transactor
 }
 
-func NewTeamService(userRepo repository.UserRepository, teamRepo repository.TeamRepository) TeamService {
+func NewTeamService(userRepo repository.UserRepository, teamRepo repository.TeamRepository, codeownersRepo repository.CodeownersRepository, reviewRulesRepo repository.ReviewRulesRepository, tx repository.Transactor) TeamService {
 	return &teamService{
-		userRepo: userRepo,
-		teamRepo: teamRepo,
+		userRepo:        userRepo,
+		teamRepo:        teamRepo,
+		codeownersRepo:  codeownersRepo,
+		reviewRulesRepo: reviewRulesRepo,
+		tx:              tx,
 	}
 }
 
 type TeamService interface {
 	Create(ctx context.Context, input CreateTeamInput) (*domain.Team, error)
 	Get(ctx context.Context, teamName string) (*domain.Team, error)
+	Import(ctx context.Context, input ImportTeamsInput) (*domain.TeamImportResult, error)
+	Update(ctx context.Context, input UpdateTeamInput) (*domain.Team, error)
+	GetCodeowners(ctx context.Context, teamName string) (*domain.Codeowners, error)
+	SetCodeowners(ctx context.Context, teamName, content string) (*domain.Codeowners, []domain.ImportRowError, error)
+	GetReviewRules(ctx context.Context, teamName string) (*domain.ReviewRules, error)
+	SetReviewRules(ctx context.Context, rules domain.ReviewRules) (*domain.ReviewRules, []domain.ImportRowError, error)
 }
 
 func (s *teamService) Create(ctx context.Context, input CreateTeamInput) (*domain.Team, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.Create")
+	defer span.End()
+
+	if err := authorize(ctx, actionManageTeams, input.Name); err != nil {
+		return nil, err
+	}
+
 	_, err := s.teamRepo.GetByName(ctx, input.Name)
 	if err == nil {
 		return nil, domain.ErrTeamExists
@@ -45,53 +90,52 @@ func (s *teamService) Create(ctx context.Context, input CreateTeamInput) (*domai
 	}
 
 	team := &domain.Team{
-		Name:    input.Name,
-		Members: make([]domain.TeamMember, 0, len(input.Members)),
+		Name:           input.Name,
+		CapacityPolicy: input.CapacityPolicy,
+		Members:        make([]domain.TeamMember, 0, len(input.Members)),
 	}
-
-	err = s.teamRepo.Create(ctx, team)
-	if err != nil {
-		return nil, err
+	if team.CapacityPolicy == "" {
+		team.CapacityPolicy = domain.CapacityPolicyReject
 	}
 
-	for _, member := range input.Members {
-		user, err := s.userRepo.GetByID(ctx, member.UserID)
-		if err != nil && !errors.Is(err, repository.ErrNotFound) {
-			return nil, err
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.teamRepo.Create(ctx, team); err != nil {
+			return err
 		}
 
-		if user == nil {
-			user = &domain.User{
-				ID:       member.UserID,
+		for _, member := range input.Members {
+			if err := s.saveMember(ctx, input.Name, member); err != nil {
+				return err
+			}
+
+			team.Members = append(team.Members, domain.TeamMember{
+				UserID:   member.UserID,
 				Username: member.Username,
-				TeamName: input.Name,
 				IsActive: member.IsActive,
-			}
-			err := s.userRepo.Create(ctx, user)
-			if err != nil {
-				return nil, err
-			}
-		} else {
-			user.Username = member.Username
-			user.TeamName = input.Name
-			user.IsActive = member.IsActive
-			err := s.userRepo.Update(ctx, user)
-			if err != nil {
-				return nil, err
-			}
+			})
 		}
-
-		team.Members = append(team.Members, domain.TeamMember{
-			UserID:   member.UserID,
-			Username: member.Username,
-			IsActive: member.IsActive,
-		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	logger.FromContext(ctx).Info("team created",
+		zap.String("team_name", team.Name),
+		zap.Int("members", len(team.Members)),
+	)
+
 	return team, nil
 }
 
 func (s *teamService) Get(ctx context.Context, teamName string) (*domain.Team, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.Get")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, teamName); err != nil {
+		return nil, err
+	}
+
 	team, err := s.teamRepo.GetByName(ctx, teamName)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -116,3 +160,330 @@ func (s *teamService) Get(ctx context.Context, teamName string) (*domain.Team, e
 
 	return team, nil
 }
+
+func (s *teamService) Update(ctx context.Context, input UpdateTeamInput) (*domain.Team, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.Update")
+	defer span.End()
+
+	if err := authorize(ctx, actionConfigureTeam, input.Name); err != nil {
+		return nil, err
+	}
+
+	team, err := s.teamRepo.GetByName(ctx, input.Name)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if input.CapacityPolicy != nil {
+		team.CapacityPolicy = *input.CapacityPolicy
+	}
+	if input.PartnerTeams != nil {
+		for _, partner := range *input.PartnerTeams {
+			if _, err := s.teamRepo.GetByName(ctx, partner); err != nil {
+				if errors.Is(err, repository.ErrNotFound) {
+					return nil, fmt.Errorf("%w: partner team %s", domain.ErrNotFound, partner)
+				}
+				return nil, err
+			}
+		}
+		team.PartnerTeams = *input.PartnerTeams
+	}
+	if input.Mentoring != nil {
+		team.Mentoring = *input.Mentoring
+	}
+
+	if err := s.teamRepo.Update(ctx, team); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("team updated",
+		zap.String("team_name", team.Name),
+		zap.String("capacity_policy", string(team.CapacityPolicy)),
+		zap.Strings("partner_teams", team.PartnerTeams),
+		zap.Bool("mentoring", team.Mentoring),
+	)
+
+	return s.Get(ctx, team.Name)
+}
+
+func (s *teamService) GetCodeowners(ctx context.Context, teamName string) (*domain.Codeowners, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetCodeowners")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, teamName); err != nil {
+		return nil, err
+	}
+
+	file, err := s.codeownersRepo.Get(ctx, teamName)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+// SetCodeowners replaces the CODEOWNERS file of the team. The file is rejected
+// with domain.ErrInvalidCodeowners and line errors if a line cannot be parsed
+// or names an owner that is neither a user nor a team.
+func (s *teamService) SetCodeowners(ctx context.Context, teamName, content string) (*domain.Codeowners, []domain.ImportRowError, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SetCodeowners")
+	defer span.End()
+
+	if err := authorize(ctx, actionConfigureTeam, teamName); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	rs, rowErrs, err := codeowners.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, []domain.ImportRowError{{Message: err.Error()}}, domain.ErrInvalidCodeowners
+	}
+
+	var owners []string
+	for _, rule := range rs.Rules() {
+		owners = append(owners, rule.Owners...)
+	}
+	_, unknown, err := resolveOwners(ctx, s.userRepo, s.teamRepo, owners)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, rule := range rs.Rules() {
+		for _, o := range rule.Owners {
+			if slices.Contains(unknown, o) {
+				rowErrs = append(rowErrs, domain.ImportRowError{Row: rule.Line, Field: "owners", Message: fmt.Sprintf("unknown user or team %q", o)})
+			}
+		}
+	}
+
+	if len(rowErrs) > 0 {
+		slices.SortStableFunc(rowErrs, func(a, b domain.ImportRowError) int { return a.Row - b.Row })
+		return nil, rowErrs, domain.ErrInvalidCodeowners
+	}
+
+	file := &domain.Codeowners{TeamName: teamName, Content: content, UpdatedAt: time.Now()}
+	if err := s.codeownersRepo.Save(ctx, file); err != nil {
+		return nil, nil, err
+	}
+
+	logger.FromContext(ctx).Info("codeowners updated",
+		zap.String("team_name", teamName),
+		zap.Int("rules", len(rs.Rules())),
+	)
+
+	return file, nil, nil
+}
+
+func (s *teamService) GetReviewRules(ctx context.Context, teamName string) (*domain.ReviewRules, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.GetReviewRules")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, teamName); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.teamRepo.GetByName(ctx, teamName); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s.reviewRulesRepo.Get(ctx, teamName)
+}
+
+// SetReviewRules replaces the review rules of the team. Invalid entries are
+// returned as row errors together with domain.ErrInvalidReviewRules.
+func (s *teamService) SetReviewRules(ctx context.Context, rules domain.ReviewRules) (*domain.ReviewRules, []domain.ImportRowError, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.SetReviewRules")
+	defer span.End()
+
+	if err := authorize(ctx, actionConfigureTeam, rules.TeamName); err != nil {
+		return nil, nil, err
+	}
+
+	if _, err := s.teamRepo.GetByName(ctx, rules.TeamName); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil, domain.ErrNotFound
+		}
+		return nil, nil, err
+	}
+
+	rowErrs, err := validateReviewRules(ctx, s.userRepo, &rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rowErrs) > 0 {
+		return nil, rowErrs, domain.ErrInvalidReviewRules
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.reviewRulesRepo.Save(ctx, &rules)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	logger.FromContext(ctx).Info("review rules updated",
+		zap.String("team_name", rules.TeamName),
+		zap.Int("always_include", len(rules.AlwaysInclude)),
+		zap.Int("never_pair", len(rules.NeverPair)),
+		zap.Bool("require_senior", rules.RequireSenior),
+	)
+
+	saved, err := s.reviewRulesRepo.Get(ctx, rules.TeamName)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return saved, nil, nil
+}
+
+// Import validates every team and member of the document before writing
+// anything and then creates all of them in one transaction. Validation
+// problems are returned in the result together with domain.ErrInvalidImport.
+func (s *teamService) Import(ctx context.Context, input ImportTeamsInput) (*domain.TeamImportResult, error) {
+	ctx, span := tracer.Start(ctx, "TeamService.Import")
+	defer span.End()
+
+	result := &domain.TeamImportResult{DryRun: input.DryRun}
+
+	teams, rowErrs, err := teamimport.Parse(input.Body, input.Format)
+	if err != nil {
+		result.Errors = []domain.ImportRowError{{Message: err.Error()}}
+		return result, domain.ErrInvalidImport
+	}
+	result.Errors = rowErrs
+
+	if len(teams) == 0 {
+		result.Errors = append(result.Errors, domain.ImportRowError{Message: "document contains no teams"})
+	}
+
+	seenTeams := make(map[string]bool, len(teams))
+	userRows := make(map[string]int)
+	var userIDs []string
+
+	for _, team := range teams {
+		if team.Name == "" {
+			result.Errors = append(result.Errors, domain.ImportRowError{Row: team.Row, Field: "team_name", Message: "is required"})
+			continue
+		}
+
+		if err := authorize(ctx, actionManageTeams, team.Name); err != nil {
+			return nil, err
+		}
+
+		if seenTeams[team.Name] {
+			result.Errors = append(result.Errors, domain.ImportRowError{Row: team.Row, Field: "team_name", Message: "team is listed more than once"})
+		}
+		seenTeams[team.Name] = true
+
+		_, err := s.teamRepo.GetByName(ctx, team.Name)
+		if err == nil {
+			result.Errors = append(result.Errors, domain.ImportRowError{Row: team.Row, Field: "team_name", Message: "team already exists"})
+		} else if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+
+		for _, m := range team.Members {
+			if m.UserID == "" {
+				result.Errors = append(result.Errors, domain.ImportRowError{Row: m.Row, Field: "user_id", Message: "is required"})
+				continue
+			}
+			if m.Username == "" {
+				result.Errors = append(result.Errors, domain.ImportRowError{Row: m.Row, Field: "username", Message: "is required"})
+			}
+			if _, ok := userRows[m.UserID]; ok {
+				result.Errors = append(result.Errors, domain.ImportRowError{Row: m.Row, Field: "user_id", Message: "user is listed more than once"})
+				continue
+			}
+			userRows[m.UserID] = m.Row
+			userIDs = append(userIDs, m.UserID)
+		}
+	}
+
+	existing, err := s.userRepo.ListByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range existing {
+		if u.Deleted() {
+			result.Errors = append(result.Errors, domain.ImportRowError{Row: userRows[u.ID], Field: "user_id", Message: "user has been offboarded"})
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, domain.ErrInvalidImport
+	}
+	result.TeamsCreated = len(teams)
+	result.UsersUpdated = len(existing)
+	result.UsersCreated = len(userIDs) - len(existing)
+
+	if input.DryRun {
+		return result, nil
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		for _, team := range teams {
+			if err := s.teamRepo.Create(ctx, &domain.Team{Name: team.Name}); err != nil {
+				return err
+			}
+			for _, m := range team.Members {
+				member := CreateTeamMemberInput{UserID: m.UserID, Username: m.Username, IsActive: m.IsActive}
+				if err := s.saveMember(ctx, team.Name, member); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("teams imported",
+		zap.Int("teams", result.TeamsCreated),
+		zap.Int("users_created", result.UsersCreated),
+		zap.Int("users_updated", result.UsersUpdated),
+	)
+
+	return result, nil
+}
+
+// saveMember creates the user or moves an existing one into teamName.
+func (s *teamService) saveMember(ctx context.Context, teamName string, member CreateTeamMemberInput) error {
+	user, err := s.userRepo.GetByID(ctx, member.UserID)
+	if err != nil && !errors.Is(err, repository.ErrNotFound) {
+		return err
+	}
+
+	if user == nil {
+		user = &domain.User{
+			ID:       member.UserID,
+			Username: member.Username,
+			TeamName: teamName,
+			IsActive: member.IsActive,
+		}
+		return s.userRepo.Create(ctx, user)
+	}
+
+	if user.Deleted() {
+		return domain.ErrUserDeleted
+	}
+
+	user.Username = member.Username
+	user.TeamName = teamName
+	user.IsActive = member.IsActive
+	return s.userRepo.Update(ctx, user)
+}
@@ -2,27 +2,138 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
 
 	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
 	"github.com/CodebyTecs/pr-assign-service/internal/repository"
 )
 
+type ListUsersInput struct {
+	TeamName string
+	IsActive *bool
+	Query    string
+	Cursor   string
+	Limit    int
+}
+
+type OffboardUserInput struct {
+	UserID    string
+	Anonymize bool
+}
+
+// PatchUserInput changes user settings; nil fields are left as they are.
+// A MaxOpenReviews of zero removes the limit.
+type PatchUserInput struct {
+	UserID         string
+	IsActive       *bool
+	MaxOpenReviews *int
+	Seniority      *domain.Seniority
+}
+
+type AddUnavailabilityInput struct {
+	UserID   string
+	StartsAt time.Time
+	EndsAt   time.Time
+	Reason   string
+}
+
 type UserService interface {
 	UpdateActivity(ctx context.Context, userID string, isActive bool) (*domain.User, error)
+	Patch(ctx context.Context, input PatchUserInput) (*domain.User, error)
+	Get(ctx context.Context, userID string) (*domain.User, error)
+	List(ctx context.Context, input ListUsersInput) (*domain.UserPage, error)
+	Offboard(ctx context.Context, input OffboardUserInput) (*domain.OffboardResult, error)
+	AddUnavailability(ctx context.Context, input AddUnavailabilityInput) (*domain.Unavailability, error)
+	ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error)
+	RemoveUnavailability(ctx context.Context, userID string, id int64) error
+	ListTags(ctx context.Context) ([]domain.Tag, error)
+	CreateTag(ctx context.Context, tag domain.Tag) (*domain.Tag, error)
+	GetTags(ctx context.Context, userID string) (*domain.UserTags, error)
+	SetTags(ctx context.Context, userID string, tags []string) (*domain.UserTags, error)
 }
 
 type userService struct {
-	repo repository.UserRepository
+	repo               repository.UserRepository
+	prRepo             repository.PRRepository
+	unavailabilityRepo repository.UnavailabilityRepository
+	tokenRepo          repository.TokenRepository
+	tx                 repository.Transactor
+	selector           *reviewerSelector
 }
 
-func NewUserService(repository repository.UserRepository) UserService {
+func NewUserService(repository repository.UserRepository, teamRepo repository.TeamRepository, prRepo repository.PRRepository, unavailabilityRepo repository.UnavailabilityRepository, reviewRulesRepo repository.ReviewRulesRepository, tokenRepo repository.TokenRepository, tx repository.Transactor) UserService {
 	return &userService{
-		repo: repository,
+		repo:               repository,
+		prRepo:             prRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		tokenRepo:          tokenRepo,
+		tx:                 tx,
+		selector:           newReviewerSelector(repository, teamRepo, prRepo, unavailabilityRepo, reviewRulesRepo),
 	}
 }
 
 func (s *userService) UpdateActivity(ctx context.Context, userID string, isActive bool) (*domain.User, error) {
+	return s.Patch(ctx, PatchUserInput{UserID: userID, IsActive: &isActive})
+}
+
+func (s *userService) Patch(ctx context.Context, input PatchUserInput) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Patch")
+	defer span.End()
+
+	user, err := s.managedUser(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Deleted() {
+		return nil, domain.ErrUserDeleted
+	}
+
+	if input.IsActive != nil {
+		user.IsActive = *input.IsActive
+	}
+	if input.MaxOpenReviews != nil {
+		user.MaxOpenReviews = input.MaxOpenReviews
+		if *input.MaxOpenReviews == 0 {
+			user.MaxOpenReviews = nil
+		}
+	}
+	if input.Seniority != nil {
+		user.Seniority = *input.Seniority
+	}
+
+	err = s.repo.Update(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []zap.Field{
+		zap.String("user_id", user.ID),
+		zap.Bool("is_active", user.IsActive),
+		zap.String("seniority", string(user.Seniority)),
+	}
+	if user.MaxOpenReviews != nil {
+		fields = append(fields, zap.Int("max_open_reviews", *user.MaxOpenReviews))
+	}
+	logger.FromContext(ctx).Info("user updated", fields...)
+
+	return user, nil
+}
+
+// managedUser loads a user the caller may manage.
+func (s *userService) managedUser(ctx context.Context, userID string) (*domain.User, error) {
+	if err := authorizeUserLookup(ctx); err != nil {
+		return nil, err
+	}
+
 	user, err := s.repo.GetByID(ctx, userID)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
@@ -31,12 +142,454 @@ func (s *userService) UpdateActivity(ctx context.Context, userID string, isActiv
 		return nil, err
 	}
 
-	user.IsActive = isActive
+	if err := authorize(ctx, actionManageUsers, user.TeamName); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
 
-	err = s.repo.Update(ctx, user)
+// selfManagedUser loads a user whose own settings the caller may manage:
+// the user themselves or someone who may manage users of their team.
+func (s *userService) selfManagedUser(ctx context.Context, userID string) (*domain.User, error) {
+	if err := authorizeSelfLookup(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	if err := authorizeSelf(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *userService) Get(ctx context.Context, userID string) (*domain.User, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Get")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	user, err := s.repo.GetByID(ctx, userID)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
 		return nil, err
 	}
 
 	return user, nil
 }
+
+func (s *userService) List(ctx context.Context, input ListUsersInput) (*domain.UserPage, error) {
+	ctx, span := tracer.Start(ctx, "UserService.List")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	limit := pageLimit(input.Limit)
+	filter := domain.UserListFilter{
+		TeamName: input.TeamName,
+		IsActive: input.IsActive,
+		Query:    input.Query,
+		Limit:    limit + 1,
+	}
+	if input.Cursor != "" {
+		after, err := decodeUserCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	users, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &domain.UserPage{Users: users}
+	if len(users) > limit {
+		page.Users = users[:limit]
+		page.NextCursor = encodeUserCursor(users[limit-1])
+	}
+
+	return page, nil
+}
+
+// Offboard hands the user's open reviews over to teammates, removing the user
+// from pull requests that have no replacement, and then soft-deletes the user.
+// With Anonymize the username is replaced; the user id is kept so that pull
+// requests and stats stay consistent.
+func (s *userService) Offboard(ctx context.Context, input OffboardUserInput) (*domain.OffboardResult, error) {
+	ctx, span := tracer.Start(ctx, "UserService.Offboard")
+	defer span.End()
+
+	user, err := s.managedUser(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Deleted() {
+		return nil, domain.ErrUserDeleted
+	}
+
+	result := &domain.OffboardResult{
+		User:        user,
+		Reassigned:  []domain.ReviewerReplacement{},
+		RemovedFrom: []string{},
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		prIDs, err := s.openReviews(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		for _, id := range prIDs {
+			if err := s.releaseReview(ctx, id, user, result); err != nil {
+				return err
+			}
+		}
+
+		now := time.Now()
+		user.IsActive = false
+		user.DeletedAt = &now
+		if input.Anonymize {
+			user.Username = anonymizedUsername(user.ID)
+		}
+
+		if err := s.repo.Update(ctx, user); err != nil {
+			return err
+		}
+
+		result.RevokedTokens, err = s.tokenRepo.RevokeByUser(ctx, user.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("user offboarded",
+		zap.String("user_id", user.ID),
+		zap.Bool("anonymized", input.Anonymize),
+		zap.Int("reassigned", len(result.Reassigned)),
+		zap.Int("removed_from", len(result.RemovedFrom)),
+		zap.Int("revoked_tokens", result.RevokedTokens),
+	)
+
+	return result, nil
+}
+
+// openReviews returns the ids of all open pull requests reviewed by userID.
+func (s *userService) openReviews(ctx context.Context, userID string) ([]string, error) {
+	filter := domain.PRListFilter{
+		ReviewerID: userID,
+		Status:     domain.PRStatusOpen,
+		Sort:       domain.SortAsc,
+		Limit:      MaxPageSize,
+	}
+
+	var ids []string
+	for {
+		prs, err := s.prRepo.List(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			ids = append(ids, pr.ID)
+		}
+		if len(prs) < filter.Limit {
+			return ids, nil
+		}
+
+		last := prs[len(prs)-1]
+		filter.After = &domain.PRCursor{CreatedAt: *last.CreatedAt, ID: last.ID}
+	}
+}
+
+func (s *userService) releaseReview(ctx context.Context, prID string, user *domain.User, result *domain.OffboardResult) error {
+	pr, err := s.prRepo.GetByID(ctx, prID)
+	if err != nil {
+		return err
+	}
+
+	idx := slices.Index(pr.Reviewers, user.ID)
+	if idx == -1 {
+		// Shadow reviews are dropped without replacement or event.
+		if !slices.Contains(pr.ShadowReviewers, user.ID) {
+			return nil
+		}
+		pr.ShadowReviewers = slices.DeleteFunc(pr.ShadowReviewers, func(id string) bool { return id == user.ID })
+		return s.prRepo.Update(ctx, pr)
+	}
+
+	event := &domain.PREvent{
+		PullRequestID: pr.ID,
+		Actor:         actorID(ctx),
+		OldReviewerID: user.ID,
+		CreatedAt:     time.Now(),
+	}
+
+	decision := newDecisionRecorder(domain.DecisionKindOffboard, newSeed())
+	ctx = withDecision(ctx, decision)
+
+	replacement, err := s.selector.pickReplacement(ctx, pr, user)
+	switch {
+	case err == nil:
+		pr.Reviewers[idx] = replacement.ID
+		event.Type = domain.PREventReassigned
+		event.NewReviewerID = replacement.ID
+		result.Reassigned = append(result.Reassigned, domain.ReviewerReplacement{PullRequestID: pr.ID, ReplacedBy: replacement.ID})
+	case errors.Is(err, domain.ErrNoCandidate), errors.Is(err, domain.ErrAllAtCapacity), errors.Is(err, domain.ErrMandatoryReviewer):
+		pr.Reviewers = slices.Delete(pr.Reviewers, idx, idx+1)
+		event.Type = domain.PREventReviewerRemoved
+		result.RemovedFrom = append(result.RemovedFrom, pr.ID)
+	default:
+		return err
+	}
+
+	if err := s.prRepo.Update(ctx, pr); err != nil {
+		return err
+	}
+	if err := s.prRepo.CreateEvent(ctx, event); err != nil {
+		return err
+	}
+
+	var selected []string
+	if event.NewReviewerID != "" {
+		selected = []string{event.NewReviewerID}
+	}
+	return s.prRepo.CreateDecision(ctx, decision.finish(ctx, pr.ID, selected, event.CreatedAt))
+}
+
+// AddUnavailability schedules an out-of-office window for the user. The user
+// keeps their is_active flag but is not picked as a reviewer inside the window.
+func (s *userService) AddUnavailability(ctx context.Context, input AddUnavailabilityInput) (*domain.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "UserService.AddUnavailability")
+	defer span.End()
+
+	user, err := s.selfManagedUser(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Deleted() {
+		return nil, domain.ErrUserDeleted
+	}
+
+	window := &domain.Unavailability{
+		UserID:    user.ID,
+		StartsAt:  input.StartsAt,
+		EndsAt:    input.EndsAt,
+		Reason:    input.Reason,
+		CreatedAt: time.Now(),
+	}
+
+	if err := s.unavailabilityRepo.Create(ctx, window); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("unavailability added",
+		zap.String("user_id", user.ID),
+		zap.Int64("unavailability_id", window.ID),
+		zap.Time("starts_at", window.StartsAt),
+		zap.Time("ends_at", window.EndsAt),
+	)
+
+	return window, nil
+}
+
+// ListUnavailability returns the user's current and upcoming out-of-office windows.
+func (s *userService) ListUnavailability(ctx context.Context, userID string) ([]domain.Unavailability, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListUnavailability")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return s.unavailabilityRepo.ListByUser(ctx, userID, time.Now())
+}
+
+func (s *userService) RemoveUnavailability(ctx context.Context, userID string, id int64) error {
+	ctx, span := tracer.Start(ctx, "UserService.RemoveUnavailability")
+	defer span.End()
+
+	if _, err := s.selfManagedUser(ctx, userID); err != nil {
+		return err
+	}
+
+	window, err := s.unavailabilityRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+	if window.UserID != userID {
+		return domain.ErrNotFound
+	}
+
+	if err := s.unavailabilityRepo.Delete(ctx, id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return domain.ErrNotFound
+		}
+		return err
+	}
+
+	logger.FromContext(ctx).Info("unavailability removed",
+		zap.String("user_id", userID),
+		zap.Int64("unavailability_id", id),
+	)
+
+	return nil
+}
+
+func (s *userService) ListTags(ctx context.Context) ([]domain.Tag, error) {
+	ctx, span := tracer.Start(ctx, "UserService.ListTags")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	return s.repo.ListTags(ctx)
+}
+
+// CreateTag adds a tag to the catalog. The catalog is shared by all teams, so
+// only admins may extend it.
+func (s *userService) CreateTag(ctx context.Context, tag domain.Tag) (*domain.Tag, error) {
+	ctx, span := tracer.Start(ctx, "UserService.CreateTag")
+	defer span.End()
+
+	if err := authorize(ctx, actionManageTeams, ""); err != nil {
+		return nil, err
+	}
+
+	tags, err := s.repo.ListTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if slices.ContainsFunc(tags, func(t domain.Tag) bool { return t.Name == tag.Name }) {
+		return nil, domain.ErrTagExists
+	}
+
+	if err := s.repo.CreateTag(ctx, &tag); err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("tag created", zap.String("tag", tag.Name))
+
+	return &tag, nil
+}
+
+func (s *userService) GetTags(ctx context.Context, userID string) (*domain.UserTags, error) {
+	ctx, span := tracer.Start(ctx, "UserService.GetTags")
+	defer span.End()
+
+	if err := authorize(ctx, actionRead, ""); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, domain.ErrNotFound
+		}
+		return nil, err
+	}
+
+	byUser, err := s.repo.TagsByUsers(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.UserTags{UserID: userID, Tags: nonNilTags(byUser[userID])}, nil
+}
+
+// SetTags replaces the user's expertise tags. Every tag must be in the catalog.
+func (s *userService) SetTags(ctx context.Context, userID string, tags []string) (*domain.UserTags, error) {
+	ctx, span := tracer.Start(ctx, "UserService.SetTags")
+	defer span.End()
+
+	user, err := s.selfManagedUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Deleted() {
+		return nil, domain.ErrUserDeleted
+	}
+
+	tags = slices.Clone(tags)
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+
+	if err := checkTags(ctx, s.repo, tags); err != nil {
+		return nil, err
+	}
+
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		return s.repo.SetTags(ctx, userID, tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	logger.FromContext(ctx).Info("user tags updated",
+		zap.String("user_id", userID),
+		zap.Strings("tags", tags),
+	)
+
+	return &domain.UserTags{UserID: userID, Tags: nonNilTags(tags)}, nil
+}
+
+// checkTags returns domain.ErrUnknownTag if any of tags is not in the catalog.
+func checkTags(ctx context.Context, repo repository.UserRepository, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	catalog, err := repo.ListTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, tag := range tags {
+		if !slices.ContainsFunc(catalog, func(t domain.Tag) bool { return t.Name == tag }) {
+			return fmt.Errorf("%w: %s", domain.ErrUnknownTag, tag)
+		}
+	}
+
+	return nil
+}
+
+func nonNilTags(tags []string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// anonymizedUsername derives a stable placeholder that does not reveal the original name.
+func anonymizedUsername(userID string) string {
+	sum := sha256.Sum256([]byte(userID))
+	return "deleted-user-" + hex.EncodeToString(sum[:4])
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+	"github.com/CodebyTecs/pr-assign-service/internal/repository"
+)
+
+func TestUpdateActivityAuthorizesBeforeLookup(t *testing.T) {
+	users := &fakeUserRepo{users: []*domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+		{ID: "f1", TeamName: "frontend", IsActive: true},
+	}}
+	svc := NewUserService(users, nil, nil, nil, nil, nil, nil)
+
+	cases := map[string]struct {
+		principal *domain.Principal
+		userID    string
+		err       error
+	}{
+		"member, existing user":   {&domain.Principal{Role: domain.RoleMember, TeamName: "backend"}, "u1", domain.ErrForbidden},
+		"member, missing user":    {&domain.Principal{Role: domain.RoleMember, TeamName: "backend"}, "nobody", domain.ErrForbidden},
+		"lead, other team":        {&domain.Principal{Role: domain.RoleTeamLead, TeamName: "backend"}, "f1", domain.ErrForbidden},
+		"lead, missing user":      {&domain.Principal{Role: domain.RoleTeamLead, TeamName: "backend"}, "nobody", domain.ErrNotFound},
+		"admin, missing user":     {&domain.Principal{Role: domain.RoleAdmin}, "nobody", domain.ErrNotFound},
+		"anonymous, missing user": {nil, "nobody", domain.ErrUnauthorized},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.principal != nil {
+				ctx = ContextWithPrincipal(ctx, tc.principal)
+			}
+
+			_, err := svc.UpdateActivity(ctx, tc.userID, false)
+
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+func TestSetTagsAuthorizesBeforeLookup(t *testing.T) {
+	deleted := time.Now()
+	users := &fakeUserRepo{users: []*domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true, DeletedAt: &deleted},
+		{ID: "f1", TeamName: "frontend", IsActive: true},
+	}}
+	svc := NewUserService(users, nil, nil, nil, nil, nil, nil)
+
+	cases := map[string]struct {
+		principal *domain.Principal
+		userID    string
+		err       error
+	}{
+		"member, other user":   {&domain.Principal{Role: domain.RoleMember, TeamName: "backend", UserID: "u1"}, "f1", domain.ErrForbidden},
+		"member, missing user": {&domain.Principal{Role: domain.RoleMember, TeamName: "backend", UserID: "u1"}, "nobody", domain.ErrForbidden},
+		"member, self":         {&domain.Principal{Role: domain.RoleMember, TeamName: "backend", UserID: "u1"}, "u1", domain.ErrUserDeleted},
+		"lead, other team":     {&domain.Principal{Role: domain.RoleTeamLead, TeamName: "backend"}, "f1", domain.ErrForbidden},
+		"lead, missing user":   {&domain.Principal{Role: domain.RoleTeamLead, TeamName: "backend"}, "nobody", domain.ErrNotFound},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			ctx := ContextWithPrincipal(context.Background(), tc.principal)
+
+			_, err := svc.SetTags(ctx, tc.userID, nil)
+
+			assert.ErrorIs(t, err, tc.err)
+		})
+	}
+}
+
+type fakeOffboardUserRepo struct {
+	fakeUserRepo
+	updated *domain.User
+}
+
+func (f *fakeOffboardUserRepo) Update(ctx context.Context, user *domain.User) error {
+	f.updated = user
+	return nil
+}
+
+type fakeTokenRepo struct {
+	repository.TokenRepository
+	revokedFor []string
+}
+
+func (f *fakeTokenRepo) RevokeByUser(ctx context.Context, userID string) (int, error) {
+	if !inFakeTx(ctx) {
+		return 0, errors.New("revoked outside the offboarding transaction")
+	}
+	f.revokedFor = append(f.revokedFor, userID)
+	return 2, nil
+}
+
+func TestOffboardRevokesTokens(t *testing.T) {
+	users := &fakeOffboardUserRepo{fakeUserRepo: fakeUserRepo{users: []*domain.User{
+		{ID: "u1", TeamName: "backend", IsActive: true},
+	}}}
+	tokens := &fakeTokenRepo{}
+	svc := NewUserService(users, nil, &fakeListPRRepo{}, nil, nil, tokens, fakeTx{})
+	ctx := ContextWithPrincipal(context.Background(), &domain.Principal{Role: domain.RoleAdmin})
+
+	result, err := svc.Offboard(ctx, OffboardUserInput{UserID: "u1"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"u1"}, tokens.revokedFor)
+	assert.Equal(t, 2, result.RevokedTokens)
+	assert.True(t, users.updated.Deleted())
+}
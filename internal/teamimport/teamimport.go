@@ -0,0 +1,215 @@
+// Package teamimport parses bulk team import documents.
+//
+// YAML documents list teams with their members:
+//
+//	teams:
+//	  - team_name: backend
+//	    members:
+//	      - user_id: u1
+//	        username: Alice
+//	        is_active: true
+//
+// CSV documents have a header row and one member per row:
+//
+//	team_name,user_id,username,is_active
+//	backend,u1,Alice,true
+//
+// is_active is optional in both formats and defaults to true.
+package teamimport
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/domain"
+)
+
+type Format string
+
+const (
+	FormatYAML Format = "yaml"
+	FormatCSV  Format = "csv"
+)
+
+var ErrUnsupportedFormat = errors.New("unsupported import format")
+
+// ParseFormat accepts a format name (yaml, yml, csv) or a media type.
+func ParseFormat(s string) (Format, error) {
+	if mt, _, err := mime.ParseMediaType(s); err == nil {
+		s = mt
+	}
+
+	switch strings.ToLower(s) {
+	case "yaml", "yml", "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return FormatYAML, nil
+	case "csv", "text/csv":
+		return FormatCSV, nil
+	default:
+		return "", ErrUnsupportedFormat
+	}
+}
+
+// Parse reads teams from r. Values that cannot be read are reported as row
+// errors; a document that cannot be read at all is returned as an error.
+func Parse(r io.Reader, format Format) ([]domain.TeamImportTeam, []domain.ImportRowError, error) {
+	switch format {
+	case FormatYAML:
+		return parseYAML(r)
+	case FormatCSV:
+		return parseCSV(r)
+	default:
+		return nil, nil, ErrUnsupportedFormat
+	}
+}
+
+type yamlDocument struct {
+	Teams []yamlTeam `yaml:"teams"`
+}
+
+type yamlTeam struct {
+	Line    int          `yaml:"-"`
+	Name    string       `yaml:"team_name"`
+	Members []yamlMember `yaml:"members"`
+}
+
+func (t *yamlTeam) UnmarshalYAML(n *yaml.Node) error {
+	type plain yamlTeam
+	if err := n.Decode((*plain)(t)); err != nil {
+		return err
+	}
+	t.Line = n.Line
+	return nil
+}
+
+type yamlMember struct {
+	Line     int    `yaml:"-"`
+	UserID   string `yaml:"user_id"`
+	Username string `yaml:"username"`
+	IsActive *bool  `yaml:"is_active"`
+}
+
+func (m *yamlMember) UnmarshalYAML(n *yaml.Node) error {
+	type plain yamlMember
+	if err := n.Decode((*plain)(m)); err != nil {
+		return err
+	}
+	m.Line = n.Line
+	return nil
+}
+
+func parseYAML(r io.Reader) ([]domain.TeamImportTeam, []domain.ImportRowError, error) {
+	var doc yamlDocument
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("can't parse yaml: %w", err)
+	}
+
+	teams := make([]domain.TeamImportTeam, 0, len(doc.Teams))
+	for _, t := range doc.Teams {
+		team := domain.TeamImportTeam{
+			Row:     t.Line,
+			Name:    strings.TrimSpace(t.Name),
+			Members: make([]domain.TeamImportMember, 0, len(t.Members)),
+		}
+		for _, m := range t.Members {
+			isActive := true
+			if m.IsActive != nil {
+				isActive = *m.IsActive
+			}
+			team.Members = append(team.Members, domain.TeamImportMember{
+				Row:      m.Line,
+				UserID:   strings.TrimSpace(m.UserID),
+				Username: strings.TrimSpace(m.Username),
+				IsActive: isActive,
+			})
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, nil, nil
+}
+
+var csvColumns = []string{"team_name", "user_id", "username", "is_active"}
+
+func parseCSV(r io.Reader) ([]domain.TeamImportTeam, []domain.ImportRowError, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("can't parse csv: %w", err)
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range csvColumns[:3] {
+		if _, ok := index[name]; !ok {
+			return nil, nil, fmt.Errorf("csv header must contain %s", strings.Join(csvColumns, ","))
+		}
+	}
+
+	var (
+		teams   []domain.TeamImportTeam
+		rowErrs []domain.ImportRowError
+		byName  = make(map[string]int)
+	)
+
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("can't parse csv: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+
+		field := func(name string) string {
+			i, ok := index[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		member := domain.TeamImportMember{
+			Row:      line,
+			UserID:   field("user_id"),
+			Username: field("username"),
+			IsActive: true,
+		}
+		if v := field("is_active"); v != "" {
+			isActive, err := strconv.ParseBool(v)
+			if err != nil {
+				rowErrs = append(rowErrs, domain.ImportRowError{Row: line, Field: "is_active", Message: "must be true or false"})
+			}
+			member.IsActive = isActive
+		}
+
+		name := field("team_name")
+		i, ok := byName[name]
+		if !ok {
+			i = len(teams)
+			byName[name] = i
+			teams = append(teams, domain.TeamImportTeam{Row: line, Name: name})
+		}
+		teams[i].Members = append(teams[i].Members, member)
+	}
+
+	return teams, rowErrs, nil
+}
@@ -0,0 +1,74 @@
+package teamimport
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseYAML(t *testing.T) {
+	doc := `teams:
+  - team_name: backend
+    members:
+      - user_id: u1
+        username: Alice
+      - user_id: u2
+        username: Bob
+        is_active: false
+`
+	teams, rowErrs, err := Parse(strings.NewReader(doc), FormatYAML)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrs)
+	require.Len(t, teams, 1)
+
+	assert.Equal(t, "backend", teams[0].Name)
+	assert.Equal(t, 2, teams[0].Row)
+	require.Len(t, teams[0].Members, 2)
+	assert.True(t, teams[0].Members[0].IsActive)
+	assert.False(t, teams[0].Members[1].IsActive)
+	assert.Equal(t, 6, teams[0].Members[1].Row)
+}
+
+func TestParseCSVGroupsRowsByTeam(t *testing.T) {
+	doc := "team_name,user_id,username\nbackend,u1,Alice\nfrontend,u2,Bob\nbackend,u3,Carol\n"
+
+	teams, rowErrs, err := Parse(strings.NewReader(doc), FormatCSV)
+	require.NoError(t, err)
+	assert.Empty(t, rowErrs)
+	require.Len(t, teams, 2)
+
+	assert.Equal(t, "backend", teams[0].Name)
+	require.Len(t, teams[0].Members, 2)
+	assert.Equal(t, 4, teams[0].Members[1].Row)
+	assert.True(t, teams[0].Members[1].IsActive)
+}
+
+func TestParseCSVInvalidBool(t *testing.T) {
+	doc := "team_name,user_id,username,is_active\nbackend,u1,Alice,maybe\n"
+
+	_, rowErrs, err := Parse(strings.NewReader(doc), FormatCSV)
+	require.NoError(t, err)
+	require.Len(t, rowErrs, 1)
+	assert.Equal(t, 2, rowErrs[0].Row)
+	assert.Equal(t, "is_active", rowErrs[0].Field)
+}
+
+func TestParseCSVMissingHeader(t *testing.T) {
+	_, _, err := Parse(strings.NewReader("team,user\nbackend,u1\n"), FormatCSV)
+	assert.Error(t, err)
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("text/csv; charset=utf-8")
+	require.NoError(t, err)
+	assert.Equal(t, FormatCSV, f)
+
+	f, err = ParseFormat("yml")
+	require.NoError(t, err)
+	assert.Equal(t, FormatYAML, f)
+
+	_, err = ParseFormat("application/json")
+	assert.ErrorIs(t, err, ErrUnsupportedFormat)
+}
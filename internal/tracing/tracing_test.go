@@ -0,0 +1,26 @@
+package tracing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTracesURL(t *testing.T) {
+	cases := map[string]string{
+		"http://otel-collector:4318":         "http://otel-collector:4318/v1/traces",
+		"https://otel-collector:4318/":       "https://otel-collector:4318/v1/traces",
+		"https://collector.example.com/otlp": "https://collector.example.com/otlp/v1/traces",
+	}
+	for endpoint, want := range cases {
+		got, err := tracesURL(endpoint)
+		require.NoError(t, err, endpoint)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestTracesURLRejectsHostPort(t *testing.T) {
+	_, err := tracesURL("otel-collector:4318")
+	assert.Error(t, err)
+}
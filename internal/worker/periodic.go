@@ -0,0 +1,59 @@
+package worker
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/CodebyTecs/pr-assign-service/internal/logger"
+)
+
+// Periodic calls fn every interval until its context is cancelled.
+type Periodic struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+	log      *zap.Logger
+
+	running atomic.Bool
+}
+
+func NewPeriodic(name string, interval time.Duration, log *zap.Logger, fn func(ctx context.Context) error) *Periodic {
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+		log:      log.With(zap.String("worker", name)),
+	}
+}
+
+func (p *Periodic) Run(ctx context.Context) {
+	p.running.Store(true)
+	defer p.running.Store(false)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	ctx = logger.WithContext(ctx, p.log)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.fn(ctx); err != nil {
+				p.log.Warn("worker iteration failed", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (p *Periodic) Name() string {
+	return p.name
+}
+
+func (p *Periodic) Running() bool {
+	return p.running.Load()
+}
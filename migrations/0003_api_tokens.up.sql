@@ -0,0 +1,11 @@
+CREATE TABLE api_tokens (
+    token_id   TEXT PRIMARY KEY,
+    name       TEXT NOT NULL,
+    role       TEXT NOT NULL,
+    team_name  TEXT REFERENCES teams(team_name),
+    token_hash TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    revoked_at TIMESTAMPTZ
+);
+
+INSERT INTO schema_migrations (version) VALUES (3);
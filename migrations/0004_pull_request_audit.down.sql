@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pull_request_events_pr;
+DROP TABLE IF EXISTS pull_request_events;
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merged_by;
+
+DELETE FROM schema_migrations WHERE version = 4;
@@ -0,0 +1,15 @@
+ALTER TABLE pull_requests ADD COLUMN merged_by TEXT;
+
+CREATE TABLE pull_request_events (
+    event_id        BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    event_type      TEXT NOT NULL,
+    actor           TEXT NOT NULL,
+    old_reviewer_id TEXT,
+    new_reviewer_id TEXT,
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX idx_pull_request_events_pr ON pull_request_events(pull_request_id);
+
+INSERT INTO schema_migrations (version) VALUES (4);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS rate_limit_buckets;
+
+DELETE FROM schema_migrations WHERE version = 5;
@@ -0,0 +1,8 @@
+CREATE UNLOGGED TABLE rate_limit_buckets (
+    bucket_key TEXT PRIMARY KEY,
+    tokens     DOUBLE PRECISION NOT NULL,
+    allowed    BOOLEAN NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO schema_migrations (version) VALUES (5);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_idempotency_keys_expires;
+DROP TABLE IF EXISTS idempotency_keys;
+
+DELETE FROM schema_migrations WHERE version = 6;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_pull_requests_status_created;
+DROP INDEX IF EXISTS idx_pull_requests_author_created;
+DROP INDEX IF EXISTS idx_pull_requests_created;
+
+DELETE FROM schema_migrations WHERE version = 7;
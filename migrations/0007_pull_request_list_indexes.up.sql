@@ -0,0 +1,5 @@
+CREATE INDEX idx_pull_requests_created ON pull_requests(created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_author_created ON pull_requests(author_id, created_at, pull_request_id);
+CREATE INDEX idx_pull_requests_status_created ON pull_requests(status, created_at, pull_request_id);
+
+INSERT INTO schema_migrations (version) VALUES (7);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_users_username_trgm;
+DROP INDEX IF EXISTS idx_users_username;
+
+DELETE FROM schema_migrations WHERE version = 8;
@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX idx_users_username ON users(username, user_id);
+CREATE INDEX idx_users_username_trgm ON users USING GIN (username gin_trgm_ops);
+
+INSERT INTO schema_migrations (version) VALUES (8);
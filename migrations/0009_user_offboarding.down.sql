@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS deleted_at;
+
+DELETE FROM schema_migrations WHERE version = 9;
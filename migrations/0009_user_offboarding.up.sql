@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN deleted_at TIMESTAMPTZ;
+
+INSERT INTO schema_migrations (version) VALUES (9);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_user_unavailability_user;
+DROP TABLE IF EXISTS user_unavailability;
+
+DELETE FROM schema_migrations WHERE version = 10;
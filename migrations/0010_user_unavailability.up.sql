@@ -0,0 +1,13 @@
+CREATE TABLE user_unavailability (
+    unavailability_id BIGSERIAL PRIMARY KEY,
+    user_id           TEXT NOT NULL REFERENCES users(user_id),
+    starts_at         TIMESTAMPTZ NOT NULL,
+    ends_at           TIMESTAMPTZ NOT NULL,
+    reason            TEXT NOT NULL DEFAULT '',
+    created_at        TIMESTAMPTZ NOT NULL,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX idx_user_unavailability_user ON user_unavailability(user_id, ends_at);
+
+INSERT INTO schema_migrations (version) VALUES (10);
@@ -0,0 +1,4 @@
+ALTER TABLE IF EXISTS teams DROP COLUMN IF EXISTS capacity_policy;
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS max_open_reviews;
+
+DELETE FROM schema_migrations WHERE version = 11;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN max_open_reviews INT CHECK (max_open_reviews > 0);
+ALTER TABLE teams ADD COLUMN capacity_policy TEXT NOT NULL DEFAULT 'reject'
+    CHECK (capacity_policy IN ('reject', 'least_loaded'));
+
+INSERT INTO schema_migrations (version) VALUES (11);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS team_codeowners;
+
+DELETE FROM schema_migrations WHERE version = 12;
@@ -0,0 +1,7 @@
+CREATE TABLE team_codeowners (
+    team_name  TEXT PRIMARY KEY REFERENCES teams(team_name),
+    content    TEXT NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+INSERT INTO schema_migrations (version) VALUES (12);
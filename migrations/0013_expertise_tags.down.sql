@@ -0,0 +1,6 @@
+ALTER TABLE IF EXISTS pull_requests DROP COLUMN IF EXISTS required_tags;
+DROP INDEX IF EXISTS idx_user_tags_tag;
+DROP TABLE IF EXISTS user_tags;
+DROP TABLE IF EXISTS tags;
+
+DELETE FROM schema_migrations WHERE version = 13;
@@ -0,0 +1,24 @@
+CREATE TABLE tags (
+    name        TEXT PRIMARY KEY,
+    description TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE user_tags (
+    user_id TEXT NOT NULL REFERENCES users(user_id),
+    tag     TEXT NOT NULL REFERENCES tags(name),
+    PRIMARY KEY (user_id, tag)
+);
+
+CREATE INDEX idx_user_tags_tag ON user_tags(tag);
+
+ALTER TABLE pull_requests ADD COLUMN required_tags TEXT[] NOT NULL DEFAULT '{}';
+
+INSERT INTO tags (name, description) VALUES
+    ('go', 'Go'),
+    ('sql', 'SQL and database schema'),
+    ('frontend', 'Frontend'),
+    ('security', 'Security')
+ON CONFLICT DO NOTHING;
+
+INSERT INTO schema_migrations (version) VALUES (13);
@@ -0,0 +1,3 @@
+ALTER TABLE IF EXISTS teams DROP COLUMN IF EXISTS partner_teams;
+
+DELETE FROM schema_migrations WHERE version = 14;
@@ -0,0 +1,3 @@
+ALTER TABLE teams ADD COLUMN partner_teams TEXT[] NOT NULL DEFAULT '{}';
+
+INSERT INTO schema_migrations (version) VALUES (14);
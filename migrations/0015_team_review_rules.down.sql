@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS team_seniors;
+DROP TABLE IF EXISTS team_never_pairs;
+DROP TABLE IF EXISTS team_mandatory_reviewers;
+
+DELETE FROM schema_migrations WHERE version = 15;
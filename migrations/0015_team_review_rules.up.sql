@@ -0,0 +1,22 @@
+CREATE TABLE team_mandatory_reviewers (
+    team_name TEXT NOT NULL REFERENCES teams(team_name),
+    user_id   TEXT NOT NULL REFERENCES users(user_id),
+    paths     TEXT[] NOT NULL DEFAULT '{}',
+    PRIMARY KEY (team_name, user_id)
+);
+
+CREATE TABLE team_never_pairs (
+    team_name   TEXT NOT NULL REFERENCES teams(team_name),
+    author_id   TEXT NOT NULL REFERENCES users(user_id),
+    reviewer_id TEXT NOT NULL REFERENCES users(user_id),
+    PRIMARY KEY (team_name, author_id, reviewer_id),
+    CHECK (author_id <> reviewer_id)
+);
+
+CREATE TABLE team_seniors (
+    team_name TEXT NOT NULL REFERENCES teams(team_name),
+    user_id   TEXT NOT NULL REFERENCES users(user_id),
+    PRIMARY KEY (team_name, user_id)
+);
+
+INSERT INTO schema_migrations (version) VALUES (15);
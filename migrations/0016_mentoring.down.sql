@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_pull_requests_shadow_reviewers;
+ALTER TABLE IF EXISTS pull_requests DROP COLUMN IF EXISTS shadow_reviewers;
+ALTER TABLE IF EXISTS teams DROP COLUMN IF EXISTS mentoring;
+ALTER TABLE IF EXISTS users DROP COLUMN IF EXISTS seniority;
+
+DELETE FROM schema_migrations WHERE version = 16;
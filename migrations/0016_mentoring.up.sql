@@ -0,0 +1,8 @@
+ALTER TABLE users ADD COLUMN seniority TEXT NOT NULL DEFAULT 'middle'
+    CHECK (seniority IN ('junior', 'middle', 'senior'));
+ALTER TABLE teams ADD COLUMN mentoring BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE pull_requests ADD COLUMN shadow_reviewers TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_pull_requests_shadow_reviewers ON pull_requests USING GIN (shadow_reviewers);
+
+INSERT INTO schema_migrations (version) VALUES (16);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_assignment_decisions_pr;
+DROP TABLE IF EXISTS assignment_decisions;
+
+DELETE FROM schema_migrations WHERE version = 17;
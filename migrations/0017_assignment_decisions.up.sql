@@ -0,0 +1,18 @@
+CREATE TABLE assignment_decisions (
+    decision_id       BIGSERIAL PRIMARY KEY,
+    pull_request_id   TEXT NOT NULL REFERENCES pull_requests(pull_request_id),
+    kind              TEXT NOT NULL,
+    strategy          TEXT NOT NULL,
+    seed              BIGINT NOT NULL,
+    candidates        TEXT[] NOT NULL DEFAULT '{}',
+    excluded          TEXT[] NOT NULL DEFAULT '{}',
+    exclusion_reasons TEXT[] NOT NULL DEFAULT '{}',
+    selected          TEXT[] NOT NULL DEFAULT '{}',
+    actor             TEXT NOT NULL,
+    created_at        TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CHECK (cardinality(excluded) = cardinality(exclusion_reasons))
+);
+
+CREATE INDEX idx_assignment_decisions_pr ON assignment_decisions(pull_request_id);
+
+INSERT INTO schema_migrations (version) VALUES (17);
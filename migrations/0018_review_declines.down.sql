@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_pull_request_events_declined;
+ALTER TABLE pull_request_events DROP COLUMN IF EXISTS reason;
+
+DELETE FROM schema_migrations WHERE version = 18;
@@ -0,0 +1,5 @@
+ALTER TABLE pull_request_events ADD COLUMN reason TEXT;
+
+CREATE INDEX idx_pull_request_events_declined ON pull_request_events(old_reviewer_id) WHERE event_type = 'DECLINED';
+
+INSERT INTO schema_migrations (version) VALUES (18);
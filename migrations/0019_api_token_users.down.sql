@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_api_tokens_user;
+ALTER TABLE api_tokens DROP COLUMN IF EXISTS user_id;
+
+DELETE FROM schema_migrations WHERE version = 19;
@@ -0,0 +1,5 @@
+ALTER TABLE api_tokens ADD COLUMN user_id TEXT REFERENCES users(user_id);
+
+CREATE INDEX idx_api_tokens_user ON api_tokens(user_id) WHERE user_id IS NOT NULL;
+
+INSERT INTO schema_migrations (version) VALUES (19);
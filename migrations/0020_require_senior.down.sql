@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS team_seniors (
+    team_name TEXT NOT NULL REFERENCES teams(team_name),
+    user_id   TEXT NOT NULL REFERENCES users(user_id),
+    PRIMARY KEY (team_name, user_id)
+);
+
+ALTER TABLE IF EXISTS teams DROP COLUMN IF EXISTS require_senior;
+
+DELETE FROM schema_migrations WHERE version = 20;
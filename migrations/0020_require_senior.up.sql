@@ -0,0 +1,25 @@
+ALTER TABLE teams ADD COLUMN require_senior BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE teams SET require_senior = true
+WHERE team_name IN (SELECT team_name FROM team_seniors);
+
+-- Seniority is a property of the user, not of a team rule, so it is left as
+-- is. Listed seniors whose seniority is not senior are reported for review.
+DO $$
+DECLARE
+    r RECORD;
+BEGIN
+    FOR r IN
+        SELECT s.team_name, s.user_id, u.seniority
+        FROM team_seniors s
+        JOIN users u ON u.user_id = s.user_id
+        WHERE u.seniority <> 'senior'
+        ORDER BY s.team_name, s.user_id
+    LOOP
+        RAISE NOTICE 'team % lists % as senior, but their seniority is %', r.team_name, r.user_id, r.seniority;
+    END LOOP;
+END $$;
+
+DROP TABLE team_seniors;
+
+INSERT INTO schema_migrations (version) VALUES (20);